@@ -0,0 +1,136 @@
+package govalid
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// aliasRules is a named rule set registered with RegisterAlias.
+type aliasRules struct {
+	rules      string
+	params     int
+	messageKey string
+}
+
+// aliases is the registry of rule aliases, keyed by name.
+var aliases = map[string]*aliasRules{}
+
+// aliasParamPattern matches the placeholders of alias params, "$1" for
+// the first.
+var aliasParamPattern = regexp.MustCompile(`\$(\d+)`)
+
+// RegisterAlias registers name as an alias for the given rules, so that
+// tags can say `valid:"password"` instead of repeating them. Placeholders
+// "$1", "$2", ... in the rules' params take the params of the alias, as in
+// `valid:"name:32"` for the rules "required;maxlen:$1". Aliases may refer
+// to other aliases, but not to themselves.
+//
+// The rules of an alias report their errors as if they were written in the
+// tag, unless a message key is given: then the alias reports a single
+// error with that template.
+//
+// Example:
+//
+//	govalid.RegisterAlias("password", "required;minlen:8;maxlen:64;regex:'[0-9]'")
+//	govalid.RegisterAlias("name", "required;maxlen:$1")
+//	govalid.RegisterAlias("contact", "email|mobile", "contact")
+func RegisterAlias(name, rules string, messageKey ...string) error {
+	if name == "" || strings.IndexAny(name, ":;,|()'\\ \t\r\n") >= 0 || name == "not" {
+		return fmt.Errorf("register alias %q: invalid name", name)
+	}
+	if _, ok := Checkers[name]; ok {
+		return fmt.Errorf("register alias %q: a checker has this name", name)
+	}
+
+	alias := &aliasRules{rules: rules}
+	for _, match := range aliasParamPattern.FindAllStringSubmatch(rules, -1) {
+		if n, _ := strconv.Atoi(match[1]); n > alias.params {
+			alias.params = n
+		}
+	}
+	if len(messageKey) > 0 {
+		alias.messageKey = messageKey[0]
+	}
+
+	// Reading the rules checks their syntax and that they don't lead back
+	// to the alias, through the aliases registered so far, which include
+	// the alias itself.
+	previous, replaced := aliases[name]
+	aliases[name] = alias
+	s := &ruleScanner{src: rules, expanding: []string{name}}
+	if _, err := s.parse(); err != nil {
+		if replaced {
+			aliases[name] = previous
+		} else {
+			delete(aliases, name)
+		}
+		return fmt.Errorf("register alias %q: %v", name, err)
+	}
+	return nil
+}
+
+// expandAlias returns the alias rule for r if it names an alias, and r
+// otherwise. start is the offset of r.
+func (s *ruleScanner) expandAlias(r *rule, start int) (*rule, error) {
+	alias, ok := aliases[r.checker]
+	if !ok {
+		return r, nil
+	}
+	for _, name := range s.expanding {
+		if name == r.checker {
+			return nil, s.errorAt(start, "alias %q refers to itself", r.checker)
+		}
+	}
+	if len(r.params) != alias.params {
+		return nil, s.errorAt(start, "alias %q takes %d params, got %d", r.checker, alias.params, len(r.params))
+	}
+
+	expanding := append(s.expanding[:len(s.expanding):len(s.expanding)], r.checker)
+	sub := &ruleScanner{src: alias.rules, expanding: expanding}
+	rules, err := sub.parse()
+	if err != nil {
+		reason := err.Error()
+		if parseErr, ok := err.(*ParseError); ok {
+			reason = parseErr.Reason
+		}
+		return nil, s.errorAt(start, "alias %q: %s", r.checker, reason)
+	}
+
+	r.op = ruleAlias
+	r.rules = substituteParams(rules, r.params)
+	r.message = alias.messageKey
+	return r, nil
+}
+
+// substituteParams returns rules with the placeholders in their params
+// replaced by args.
+func substituteParams(rules []*rule, args []string) []*rule {
+	if len(args) == 0 || len(rules) == 0 {
+		return rules
+	}
+
+	substituted := make([]*rule, len(rules))
+	for i, r := range rules {
+		copied := *r
+		if r.params != nil {
+			copied.params = make([]string, len(r.params))
+		}
+		for j, param := range r.params {
+			copied.params[j] = aliasParamPattern.ReplaceAllStringFunc(param, func(placeholder string) string {
+				n, _ := strconv.Atoi(placeholder[1:])
+				if n < 1 || n > len(args) {
+					return placeholder
+				}
+				return args[n-1]
+			})
+		}
+		copied.rules = substituteParams(r.rules, args)
+		if r.op != ruleCheck && r.op != ruleAlias {
+			copied.checker = copied.String()
+		}
+		substituted[i] = &copied
+	}
+	return substituted
+}
@@ -0,0 +1,149 @@
+package govalid
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// registerAlias registers an alias for the duration of a test.
+func registerAlias(t *testing.T, name, rules string, messageKey ...string) {
+	t.Helper()
+	assert.Nil(t, RegisterAlias(name, rules, messageKey...))
+	t.Cleanup(func() { delete(aliases, name) })
+}
+
+func Test_RegisterAlias(t *testing.T) {
+	registerAlias(t, "password", "required;minlen:8;maxlen:64;regex:'[0-9]'")
+
+	type form struct {
+		Password string `valid:"password" label:"密码"`
+		Confirm  string `valid:"omitempty;password;equal:Password" label:"确认密码"`
+	}
+
+	_, ok := Check(form{Password: "secret123", Confirm: "secret123"})
+	assert.True(t, ok)
+
+	// The rules report like rules written in the tag.
+	errs, ok := Check(form{Password: "secret"})
+	assert.False(t, ok)
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "密码长度应大于8", errs[0].Error())
+	assert.Equal(t, "密码格式不正确", errs[1].Error())
+
+	errs, _ = Check(form{})
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "密码不能为空", errs[0].Error())
+
+	rules, err := parseRules("password;email")
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(rules))
+}
+
+func Test_RegisterAlias_Params(t *testing.T) {
+	registerAlias(t, "name", "required;maxlen:$1")
+	registerAlias(t, "score", "between:$1,$2|list:$3")
+
+	type form struct {
+		Nickname string `valid:"name:4" label:"昵称"`
+		Score    string `valid:"score:0,100,n/a" label:"分数"`
+	}
+
+	_, ok := Check(form{Nickname: "abc", Score: "n/a"})
+	assert.True(t, ok)
+
+	errs, _ := Check(form{Nickname: "abcde", Score: "101"})
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "昵称长度应小于4", errs[0].Error())
+	assert.Equal(t, "分数应在[0, 100]范围内或不是一个有效的值", errs[1].Error())
+
+	_, err := parseRules("name")
+	if assert.NotNil(t, err) {
+		assert.Equal(t, `alias "name" takes 1 params, got 0`, err.(*ParseError).Reason)
+	}
+}
+
+func Test_RegisterAlias_Nested(t *testing.T) {
+	registerAlias(t, "name", "required;maxlen:$1")
+	registerAlias(t, "shortname", "name:$1;alpha")
+
+	rules, err := parseRules("shortname:8")
+	assert.Nil(t, err)
+	assert.Equal(t, []*rule{
+		{checker: "required"},
+		{checker: "maxlen", params: []string{"8"}},
+		{checker: "alpha"},
+	}, rules)
+
+	// Inside an OR group, the alias is checked as a whole.
+	rules, err = parseRules("shortname:8|list:n/a")
+	assert.Nil(t, err)
+	assert.Equal(t, "shortname:8|list:n/a", rules[0].checker)
+}
+
+func Test_RegisterAlias_MessageKey(t *testing.T) {
+	registerAlias(t, "contact", "required;email|mobile", "contact")
+	SetMessageTemplates(map[string]string{"contact": "应为邮箱或手机号"})
+	SetMessageTemplates(map[string]string{"contact": " must be an email or mobile number"}, language.English)
+	defer RemoveMessageTemplate("contact")
+	defer RemoveMessageTemplate("contact", language.English)
+
+	type form struct {
+		Contact string `valid:"contact" label:"联系方式"`
+	}
+
+	_, ok := Check(form{Contact: "13388886666"})
+	assert.True(t, ok)
+
+	for _, contact := range []string{"", "nope"} {
+		errs, _ := Check(form{Contact: contact})
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "联系方式应为邮箱或手机号", errs[0].Error())
+	}
+
+	errs, _ := Check(form{}, language.English)
+	assert.Equal(t, "联系方式 must be an email or mobile number", errs[0].Error())
+}
+
+func Test_RegisterAlias_Invalid(t *testing.T) {
+	registerAlias(t, "a", "required;b")
+
+	// b would lead back to itself through a.
+	err := RegisterAlias("b", "a")
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), `alias "b" refers to itself`)
+	}
+	assert.NotContains(t, aliases, "b")
+
+	assert.NotNil(t, RegisterAlias("c", "c"))
+	assert.NotNil(t, RegisterAlias("d", "required;min:"))
+	assert.NotNil(t, RegisterAlias("email", "required"))
+	assert.NotNil(t, RegisterAlias("a b", "required"))
+	assert.NotNil(t, RegisterAlias("not", "required"))
+	assert.Equal(t, 1, len(aliases))
+
+	// A failed re-registration keeps the alias.
+	assert.NotNil(t, RegisterAlias("a", "a"))
+	assert.Equal(t, "required;b", aliases["a"].rules)
+}
+
+func Test_Compile_Alias(t *testing.T) {
+	registerAlias(t, "name", "required;maxlen:$1")
+
+	assert.Nil(t, Compile(reflect.TypeOf(struct {
+		Name string `valid:"name:32"`
+	}{})))
+
+	err := Compile(reflect.TypeOf(struct {
+		Name  string `valid:"name:x"`
+		Title string `valid:"name"`
+	}{}))
+	if assert.NotNil(t, err) {
+		errs := err.(*CompileError).Errors
+		assert.Equal(t, 2, len(errs))
+		assert.Equal(t, "maxlen", errs[0].Checker)
+		assert.Equal(t, "Title", errs[1].FieldPath)
+	}
+}
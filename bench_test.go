@@ -53,6 +53,6 @@ func BenchmarkParseRules(b *testing.B) {
 	const raw = "required;min:0;max:100;list:a,b,c,d,e"
 	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
-		_ = parseRules(raw)
+		_, _ = parseRules(raw)
 	}
 }
@@ -0,0 +1,347 @@
+package govalid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// Rule is a rule built in code rather than read from a tag, for rules that
+// need constants or computed params. A Rule that can't be read, such as one
+// with malformed params, is reported on its field like a malformed tag.
+type Rule struct {
+	rule *rule
+	err  error
+}
+
+// NewRule returns the rule checker:params. The params are formatted with
+// fmt.Sprint and taken as they are, so they may hold any character without
+// quoting. The checker may be an alias.
+//
+// Example:
+//
+//	govalid.NewRule("decimal", 10, 2)
+//	govalid.NewRule("datetime", time.RFC3339)
+func NewRule(checker string, params ...interface{}) Rule {
+	s := &ruleScanner{src: checker}
+	if checker == "" {
+		return Rule{err: s.errorAt(0, "missing checker name")}
+	}
+	if i := strings.IndexAny(checker, " \t\r\n:;,|()'\\"); i >= 0 {
+		return Rule{err: s.errorAt(i, "unexpected %q in checker name", checker[i])}
+	}
+
+	r := &rule{checker: checker}
+	for _, param := range params {
+		r.params = append(r.params, fmt.Sprint(param))
+	}
+	r, err := s.expandAlias(r, 0)
+	return Rule{rule: r, err: err}
+}
+
+// Required returns the required rule.
+func Required() Rule { return NewRule("required") }
+
+// Min returns the rule min:n. The bound n may be a value of any native
+// number kind, a time.Duration, a *big.Int, a *big.Float or a decimal
+// string such as a json.Number; it is formatted exactly.
+func Min(n interface{}) Rule { return numberRule("min", n) }
+
+// Max returns the rule max:n, with n a bound as in Min.
+func Max(n interface{}) Rule { return numberRule("max", n) }
+
+// Between returns the rule between:min,max, with bounds as in Min.
+func Between(min, max interface{}) Rule { return numberRule("between", min, max) }
+
+// MinLen returns the rule minlen:n.
+func MinLen(n int) Rule { return NewRule("minlen", n) }
+
+// MaxLen returns the rule maxlen:n.
+func MaxLen(n int) Rule { return NewRule("maxlen", n) }
+
+// Email returns the email rule.
+func Email() Rule { return NewRule("email") }
+
+// Mobile returns the mobile rule.
+func Mobile() Rule { return NewRule("mobile") }
+
+// List returns the rule list:values.
+func List(values ...string) Rule {
+	params := make([]interface{}, len(values))
+	for i, value := range values {
+		params[i] = value
+	}
+	return NewRule("list", params...)
+}
+
+// Regex returns the rule regex:expr.
+func Regex(expr string) Rule { return NewRule("regex", expr) }
+
+// Equal returns the rule equal:field, with field a field reference.
+func Equal(field string) Rule { return NewRule("equal", field) }
+
+// AnyOf returns the rule that passes if any of rules passes, as
+// "email|mobile" in a tag.
+func AnyOf(rules ...Rule) Rule { return group(ruleAny, rules) }
+
+// All returns the rule that passes if all of rules pass, as
+// "(alpha;minlen:3)" in a tag, for use in AnyOf.
+func All(rules ...Rule) Rule { return group(ruleAll, rules) }
+
+// Not returns the rule that passes if r fails, as "not:r" in a tag.
+func Not(r Rule) Rule { return group(ruleNot, []Rule{r}) }
+
+// group returns the composite of rules. Like parentheses in a tag, a group
+// of a single rule is that rule.
+func group(op ruleOp, rules []Rule) Rule {
+	if len(rules) == 0 {
+		return Rule{err: errors.New("empty group of rules")}
+	}
+	subs := make([]*rule, len(rules))
+	for i, r := range rules {
+		if r.err != nil {
+			return r
+		}
+		if isOmitEmpty(r.rule) {
+			return Rule{err: fmt.Errorf("%s applies to the whole field, not inside a group of rules", OmitEmpty)}
+		}
+		subs[i] = r.rule
+	}
+	if len(subs) == 1 && op != ruleNot {
+		return rules[0]
+	}
+	return Rule{rule: composite(op, subs)}
+}
+
+// numberRule returns the rule checker:bounds, with the bounds formatted by
+// formatNumber.
+func numberRule(checker string, bounds ...interface{}) Rule {
+	params := make([]interface{}, len(bounds))
+	for i, bound := range bounds {
+		param, ok := formatNumber(bound)
+		if !ok {
+			return Rule{err: fmt.Errorf("%s: bound %v is not a number", checker, bound)}
+		}
+		params[i] = param
+	}
+	return NewRule(checker, params...)
+}
+
+// formatNumber formats a number param exactly, without a trailing ".0".
+func formatNumber(n interface{}) (string, bool) {
+	switch n := n.(type) {
+	case time.Duration:
+		return formatDuration(n), true
+	case *big.Int:
+		if n == nil {
+			return "", false
+		}
+		return n.String(), true
+	case *big.Float:
+		if n == nil || n.IsInf() {
+			return "", false
+		}
+		return n.Text('g', -1), true
+	}
+
+	value := reflect.ValueOf(n)
+	switch {
+	case !value.IsValid():
+		return "", false
+	case isIntKind(value.Kind()):
+		return strconv.FormatInt(value.Int(), 10), true
+	case isUintKind(value.Kind()):
+		return strconv.FormatUint(value.Uint(), 10), true
+	case isFloatKind(value.Kind()):
+		f := value.Float()
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return "", false
+		}
+		return strconv.FormatFloat(f, 'f', -1, value.Type().Bits()), true
+	case value.Kind() == reflect.String:
+		if _, ok := parseDecimal(value.String()); !ok {
+			return "", false
+		}
+		return strings.TrimSpace(value.String()), true
+	}
+	return "", false
+}
+
+// FieldRules are the rules of a field built with Field.
+type FieldRules struct {
+	ptr     interface{}
+	rules   []*rule
+	err     error
+	labels  map[language.Tag]string
+	message string
+}
+
+// Field returns the rules of the field ptr points to, for Fields. They are
+// checked after the rules of the field's tag.
+func Field(ptr interface{}, rules ...Rule) *FieldRules {
+	f := &FieldRules{ptr: ptr, labels: make(map[language.Tag]string)}
+	for _, r := range rules {
+		if r.err != nil {
+			f.err = r.err
+			break
+		}
+		// The rules of an alias without a message of its own are checked
+		// as if they were given in its place, as in a tag.
+		if r.rule.op == ruleAlias && r.rule.message == "" {
+			f.rules = append(f.rules, r.rule.rules...)
+		} else {
+			f.rules = append(f.rules, r.rule)
+		}
+	}
+	return f
+}
+
+// Label sets the label of the field for the given language or, without
+// one, for every language. It takes precedence over the label tag.
+func (f *FieldRules) Label(label string, lang ...language.Tag) *FieldRules {
+	tag := language.Und
+	if len(lang) > 0 {
+		tag = lang[0]
+	}
+	f.labels[tag] = label
+	return f
+}
+
+// Message sets the message reported instead of the errors of the field's
+// rules, as the msg tag does.
+func (f *FieldRules) Message(message string) *FieldRules {
+	f.message = message
+	return f
+}
+
+// label returns the label of the field in the given language. f may be
+// nil.
+func (f *FieldRules) label(languageTag language.Tag) (string, bool) {
+	if f == nil {
+		return "", false
+	}
+	if label, ok := f.labels[languageTag]; ok {
+		return label, true
+	}
+	label, ok := f.labels[language.Und]
+	return label, ok
+}
+
+// FieldSet is a struct value with rules built in code for its fields.
+type FieldSet struct {
+	value  reflect.Value
+	fields []*FieldRules
+	// paths are the paths of the fields when the set was built.
+	paths []string
+}
+
+// Fields binds the field rules to the struct structPtr points to, whose
+// fields, nested or in slice elements, they were built for. It panics if a
+// field rule doesn't point to a field of the struct.
+//
+// The fields are found again each time the set is checked, by address, so
+// the rules of a slice element follow it when the slice is re-sliced. The
+// rules of a field that is no longer in the struct, such as an element an
+// append moved, apply to the field at the path it had when the set was
+// built.
+//
+// Example:
+//
+//	var f Form
+//	set := govalid.Fields(&f,
+//		govalid.Field(&f.Name, govalid.Required(), govalid.MaxLen(MaxNameLen)).Label("姓名"),
+//		govalid.Field(&f.Contact, govalid.AnyOf(govalid.Email(), govalid.Mobile())),
+//	)
+//	errs, ok := set.Check()
+func Fields(structPtr interface{}, fields ...*FieldRules) *FieldSet {
+	v := reflect.ValueOf(structPtr)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("govalid: Fields of %T, not a pointer to a struct", structPtr))
+	}
+
+	set := &FieldSet{value: v, fields: fields, paths: make([]string, len(fields))}
+	for i, f := range fields {
+		ptr := reflect.ValueOf(f.ptr)
+		if ptr.Kind() != reflect.Ptr || ptr.IsNil() {
+			panic(fmt.Sprintf("govalid: Field of %T, not a pointer to a field", f.ptr))
+		}
+		path, ok := fieldPathOf(v.Elem(), "", ptr, []reflect.Value{v})
+		if !ok {
+			panic(fmt.Sprintf("govalid: Field of %T, not a field of %T", f.ptr, structPtr))
+		}
+		set.paths[i] = path
+	}
+	return set
+}
+
+// fieldsByPath returns the rules of the fields keyed by their current
+// path, or the path they had when the set was built.
+func (s *FieldSet) fieldsByPath() map[string]*FieldRules {
+	fields := make(map[string]*FieldRules, len(s.fields))
+	for i, f := range s.fields {
+		path, ok := fieldPathOf(s.value.Elem(), "", reflect.ValueOf(f.ptr), []reflect.Value{s.value})
+		if !ok {
+			path = s.paths[i]
+		}
+		fields[path] = f
+	}
+	return fields
+}
+
+// fieldPathOf returns the path of the field ptr points to in the struct
+// value, as parseStruct walks it. pointers are the pointers followed to
+// reach value, as in nestedStruct.
+func fieldPathOf(value reflect.Value, path string, ptr reflect.Value, pointers []reflect.Value) (string, bool) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		fieldValue := value.Field(i)
+		fieldPath := joinPath(path, field.Name)
+
+		if field.PkgPath == "" && fieldValue.UnsafeAddr() == ptr.Pointer() && field.Type == ptr.Type().Elem() {
+			return fieldPath, true
+		}
+		if isNestedSlice(field.Type) {
+			for j := 0; j < fieldValue.Len(); j++ {
+				if element, pointers, ok := nestedStruct(fieldValue.Index(j), pointers); ok {
+					if p, ok := fieldPathOf(element, fieldPath+"["+strconv.Itoa(j)+"]", ptr, pointers); ok {
+						return p, true
+					}
+				}
+			}
+		}
+		if nested, pointers, ok := nestedStruct(fieldValue, pointers); ok {
+			if p, ok := fieldPathOf(nested, fieldPath, ptr, pointers); ok {
+				return p, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Check checks the struct like the package level Check, with the rules of
+// the fields.
+func (s *FieldSet) Check(lang ...language.Tag) (errs []*ErrContext, ok bool) {
+	opts := make([]Option, 0, 1)
+	if len(lang) > 0 {
+		opts = append(opts, WithLanguage(lang[0]))
+	}
+
+	errs, _ = s.CheckContext(context.Background(), opts...)
+	return errs, len(errs) == 0
+}
+
+// CheckContext checks the struct like the package level CheckContext, with
+// the rules of the fields.
+func (s *FieldSet) CheckContext(ctx context.Context, opts ...Option) ([]*ErrContext, error) {
+	o := newCheckOptions(opts...)
+	o.fields = s.fieldsByPath()
+	return check(ctx, s.value.Interface(), o)
+}
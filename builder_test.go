@@ -0,0 +1,197 @@
+package govalid
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+const testMaxNameLen = 4
+
+type builderAddress struct {
+	City string `label:"城市"`
+}
+
+type builderForm struct {
+	Name      string `valid:"required" label:"名字"`
+	Contact   string
+	Age       int
+	Address   builderAddress
+	Addresses []builderAddress
+}
+
+// =============================================================================
+// Rule builders
+// =============================================================================
+
+func Test_NewRule(t *testing.T) {
+	for _, tc := range []struct {
+		rule Rule
+		want string
+	}{
+		{Required(), "required"},
+		{MaxLen(testMaxNameLen), "maxlen:4"},
+		{Between(0, 2.5), "between:0,2.5"},
+		{Min(int64(math.MaxInt64)), "min:9223372036854775807"},
+		{Max(uint64(math.MaxUint64)), "max:18446744073709551615"},
+		{Max(float32(0.1)), "max:0.1"},
+		{Between(time.Minute, 90*time.Minute), "between:1m,1h30m"},
+		{Min(json.Number("1e-3")), "min:1e-3"},
+		{Max(new(big.Int).Lsh(big.NewInt(1), 64)), "max:18446744073709551616"},
+		{List("a,b", "c"), "list:'a,b',c"},
+		{Regex(`^\d+;$`), `regex:'^\\d+;$'`},
+		{AnyOf(Email(), All(Mobile(), MaxLen(11))), "email|(mobile;maxlen:11)"},
+		{Not(List("admin", "root")), "not:list:admin,root"},
+		{AnyOf(Email()), "email"},
+	} {
+		assert.Nil(t, tc.rule.err, tc.want)
+		assert.Equal(t, tc.want, tc.rule.rule.String())
+
+		// The rule is the one the tag would give.
+		rules, err := parseRules(tc.want)
+		assert.Nil(t, err)
+		assert.Equal(t, rules, []*rule{tc.rule.rule})
+	}
+
+	// Params are taken as they are, whatever they hold.
+	special := NewRule("list", "a;b", "c|d", "e)", "it's", " f ")
+	assert.Nil(t, special.err)
+	assert.Equal(t, []string{"a;b", "c|d", "e)", "it's", " f "}, special.rule.params)
+	rules, err := parseRules(special.rule.String())
+	assert.Nil(t, err)
+	assert.Equal(t, rules, []*rule{special.rule})
+
+	assert.NotNil(t, NewRule("").err)
+	assert.NotNil(t, NewRule("min;max").err)
+	assert.NotNil(t, NewRule("min:1").err)
+	assert.NotNil(t, AnyOf().err)
+	assert.NotNil(t, Not(NewRule("")).err)
+	assert.NotNil(t, Min("abc").err)
+	assert.NotNil(t, Max(math.NaN()).err)
+	assert.NotNil(t, Between(0, nil).err)
+	assert.NotNil(t, AnyOf(NewRule(OmitEmpty), Email()).err)
+	assert.NotNil(t, Not(NewRule(OmitEmpty)).err)
+}
+
+// =============================================================================
+// Fields
+// =============================================================================
+
+func Test_Fields(t *testing.T) {
+	var f builderForm
+	set := Fields(&f,
+		Field(&f.Name, MaxLen(testMaxNameLen)).Label("姓名").Label("Name", language.English),
+		Field(&f.Contact, AnyOf(Email(), Mobile())).Label("联系方式"),
+		Field(&f.Age, NewRule(OmitEmpty), Min(18)).Message("未成年"),
+	)
+
+	f = builderForm{Name: "Bob", Contact: "13388886666", Age: 20}
+	_, ok := set.Check()
+	assert.True(t, ok)
+
+	// The set checks the current value of the struct.
+	f = builderForm{Name: "Alice", Contact: "nope", Age: 17}
+	errs, ok := set.Check()
+	assert.False(t, ok)
+	assert.Equal(t, 3, len(errs))
+	assert.Equal(t, "姓名长度应小于4", errs[0].Error())
+	assert.Equal(t, "联系方式不是合法的电子邮箱格式或不是合法的手机号", errs[1].Error())
+	assert.Equal(t, "未成年", errs[2].Error())
+
+	// The tag rules are checked first, with the built label.
+	f = builderForm{Contact: "a@example.com"}
+	errs, _ = set.Check(language.English)
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "Name can not be empty", errs[0].Error())
+	assert.Equal(t, "Name", errs[0].FieldPath)
+}
+
+func Test_Fields_ExactBounds(t *testing.T) {
+	// Bounds above 2^53 are kept exactly, unlike as float64.
+	var f struct{ ID int64 }
+	set := Fields(&f, Field(&f.ID, Max(int64(1<<53+1))))
+
+	f.ID = 1<<53 + 1
+	_, ok := set.Check()
+	assert.True(t, ok)
+
+	f.ID = 1<<53 + 2
+	_, ok = set.Check()
+	assert.False(t, ok)
+}
+
+func Test_Fields_Nested(t *testing.T) {
+	f := builderForm{Name: "Bob", Addresses: make([]builderAddress, 2)}
+	set := Fields(&f,
+		Field(&f.Address.City, Required()),
+		Field(&f.Addresses[1].City, Required()).Label("第二城市"),
+	)
+
+	errs, _ := set.Check()
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "Address.City", errs[0].FieldPath)
+	assert.Equal(t, "城市不能为空", errs[0].Error())
+	assert.Equal(t, "Addresses[1].City", errs[1].FieldPath)
+	assert.Equal(t, "第二城市不能为空", errs[1].Error())
+
+	// Check alone doesn't see the built rules.
+	_, ok := Check(f)
+	assert.True(t, ok)
+}
+
+func Test_Fields_Pointers(t *testing.T) {
+	type form struct {
+		Address   *builderAddress
+		Addresses []*builderAddress
+	}
+	f := form{Address: &builderAddress{}, Addresses: []*builderAddress{nil, {}}}
+	set := Fields(&f,
+		Field(&f.Address.City, Required()),
+		Field(&f.Addresses[1].City, Required()),
+	)
+
+	errs, _ := set.Check()
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "Address.City", errs[0].FieldPath)
+	assert.Equal(t, "Addresses[1].City", errs[1].FieldPath)
+}
+
+func Test_Fields_Slices(t *testing.T) {
+	f := builderForm{Name: "Bob", Addresses: make([]builderAddress, 3, 4)}
+	set := Fields(&f, Field(&f.Addresses[1].City, Required()).Label("第二城市"))
+
+	// The rules follow the element when the slice is re-sliced.
+	f.Addresses = f.Addresses[1:]
+	errs, err := set.CheckContext(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "Addresses[0].City", errs[0].FieldPath)
+	assert.Equal(t, "第二城市不能为空", errs[0].Error())
+
+	// The rules of an element the slice no longer holds apply at the path
+	// it had.
+	f.Addresses = append(f.Addresses, make([]builderAddress, 4)...)
+	errs, ok := set.Check()
+	assert.False(t, ok)
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "Addresses[1].City", errs[0].FieldPath)
+}
+
+func Test_Fields_Invalid(t *testing.T) {
+	var f builderForm
+	errs, _ := Fields(&f, Field(&f.Age, Min(0), NewRule("min:"))).Check()
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "名字不能为空", errs[0].Error())
+	assert.Equal(t, "Age检查规则在第4个字符处格式错误", errs[1].Error())
+
+	other := 0
+	assert.Panics(t, func() { Fields(f) })
+	assert.Panics(t, func() { Fields(&f, Field(&other, Required())) })
+	assert.Panics(t, func() { Fields(&f, Field(f.Name, Required())) })
+}
@@ -1,6 +1,8 @@
 package govalid
 
 import (
+	"context"
+	"database/sql/driver"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -18,71 +20,294 @@ type CheckFunc func(ctx CheckerContext) *ErrContext
 // CheckerContext is the context of checker,
 // which can contains the rule of the checker and the value of the current struct field.
 type CheckerContext struct {
-	StructValue      reflect.Value
-	FieldName        string
+	// Context is the context passed to CheckContext, or
+	// context.Background() for Check.
+	Context context.Context
+
+	// StructValue is the struct that directly contains the field.
+	StructValue reflect.Value
+	// Parent is the struct enclosing StructValue. It is the zero
+	// reflect.Value for fields of the checked value itself.
+	Parent reflect.Value
+	// Root is the value passed to Check.
+	Root reflect.Value
+
+	FieldName string
+	// FieldPath is the path of the field from Root, e.g. "Items[2].Price".
+	FieldPath        string
 	FieldType        reflect.Type
 	FieldValue       interface{}
 	FieldLabel       string
 	TemplateLanguage language.Tag
+	// Index is the index of StructValue in its enclosing slice, or -1 if
+	// StructValue is not a slice element.
+	Index int
+	// StrictEmpty is set when string checkers must reject the empty
+	// string instead of accepting it. See StrictEmpty.
+	StrictEmpty bool
 
 	Rule *rule
+
+	node *structNode
+}
+
+// RootFieldPrefix marks a field reference as relative to the checked value
+// rather than to the field's own struct, e.g. "$root.Currency". When the
+// checked value is a slice, it is relative to the element the field is in.
+const RootFieldPrefix = "$root."
+
+// LookupField resolves a field reference relative to the struct of the
+// current field. A reference is a sibling field name ("Currency"), a dotted
+// path into nested structs ("Address.City"), a path relative to an
+// enclosing struct ("../Currency", "../../Currency"), or a path from the
+// checked value ("$root.Currency"). The fields of an embedded struct are
+// those of the struct embedding it, and a sibling name or dotted path that
+// doesn't resolve is looked up in the enclosing structs, innermost first.
+func (c CheckerContext) LookupField(ref string) (reflect.Value, reflect.StructField, bool) {
+	value, _, field, ok := c.lookupField(ref)
+	return value, field, ok
+}
+
+// lookupField is LookupField, also returning the struct type declaring the
+// field.
+func (c CheckerContext) lookupField(ref string) (value reflect.Value, owner reflect.Type, field reflect.StructField, ok bool) {
+	current := c.StructValue
+	node := c.node
+	// The fields of an embedded struct are promoted to the struct
+	// embedding it, so references resolve against that struct.
+	if node != nil {
+		node = node.refScope()
+		current = node.value
+	}
+
+	anchored := false
+	switch {
+	case strings.HasPrefix(ref, RootFieldPrefix):
+		current = c.Root
+		ref = strings.TrimPrefix(ref, RootFieldPrefix)
+		anchored = true
+		// The elements of a checked slice are roots of their own.
+		if current.Kind() == reflect.Slice && node != nil {
+			for node.parent != nil {
+				node = node.parent
+			}
+			current = node.value
+		}
+	default:
+		for strings.HasPrefix(ref, "../") {
+			if node == nil || node.parent == nil {
+				return reflect.Value{}, nil, reflect.StructField{}, false
+			}
+			node = node.parent.refScope()
+			current = node.value
+			ref = strings.TrimPrefix(ref, "../")
+			anchored = true
+		}
+	}
+
+	value, owner, field, ok = resolveField(current, ref)
+	// A plain reference that isn't a field of a nested struct resolves
+	// against the structs enclosing it, as it did before nested structs had
+	// references of their own.
+	for !ok && !anchored && node != nil && node.parent != nil {
+		node = node.parent.refScope()
+		value, owner, field, ok = resolveField(node.value, ref)
+	}
+	return value, owner, field, ok
+}
+
+// resolveField resolves the dotted path ref from the struct value current.
+func resolveField(current reflect.Value, ref string) (value reflect.Value, owner reflect.Type, field reflect.StructField, ok bool) {
+	for _, name := range strings.Split(ref, ".") {
+		for current.IsValid() && current.Kind() == reflect.Ptr {
+			current = current.Elem()
+		}
+		if !current.IsValid() || current.Kind() != reflect.Struct {
+			return reflect.Value{}, nil, reflect.StructField{}, false
+		}
+
+		field, ok = current.Type().FieldByName(name)
+		// Unexported fields can't be read through reflection.
+		if !ok || field.PkgPath != "" {
+			return reflect.Value{}, nil, reflect.StructField{}, false
+		}
+		owner = current.Type()
+		if len(field.Index) > 1 {
+			// A promoted field is declared by the embedded struct.
+			owner = owner.FieldByIndex(field.Index[:len(field.Index)-1]).Type
+			for owner.Kind() == reflect.Ptr {
+				owner = owner.Elem()
+			}
+		}
+		current = current.FieldByIndex(field.Index)
+	}
+	return current, owner, field, true
+}
+
+// OmitEmpty is the name of the rule that skips all later rules of a field
+// when its value is empty, e.g. `valid:"omitempty;min:1"`.
+const OmitEmpty = "omitempty"
+
+// StrictEmpty makes the string checkers (alpha, email, minlen, ...) reject
+// the empty string instead of silently accepting it. Fields that are
+// optional then need an explicit omitempty rule.
+var StrictEmpty = false
+
+// Checkers is the function list of checkers. Assigning to it registers a
+// checker without a spec; see RegisterChecker.
+var Checkers = map[string]CheckFunc{}
+
+func init() {
+	for _, spec := range builtinCheckers {
+		RegisterChecker(spec)
+	}
 }
 
-// Checkers is the function list of checkers.
-var Checkers = map[string]CheckFunc{
-	"required":     required,
-	"min":          min,
-	"max":          max,
-	"minlen":       minlen,
-	"maxlen":       maxlen,
-	"alpha":        alpha,
-	"alphanumeric": alphaNumeric,
-	"alphadash":    alphaDash,
-	"username":     userName,
-	"email":        email,
-	"ipv4":         ipv4,
-	"mobile":       mobile,
-	"tel":          tel,
-	"phone":        phone,
-	"idcard":       idCard,
-	"equal":        equal,
-	"list":         list,
+func fixedParams(min, max int, types ...ParamType) ParamSchema {
+	return ParamSchema{Min: min, Max: max, Types: types}
+}
+
+func variadicParams(min int, types ...ParamType) ParamSchema {
+	return ParamSchema{Min: min, Variadic: true, Types: types}
+}
+
+// builtinCheckers are the specs of the built-in checkers.
+var builtinCheckers = []CheckerSpec{
+	{Name: OmitEmpty, Func: omitEmpty, Description: "Skips the remaining rules if the field is empty."},
+	{Name: NoTypeRules, Func: noTypeRules, Description: "Opts the field out of the rules registered for its type."},
+	{Name: "required", Func: required, Description: "Must not be empty: zero, nil or of zero length."},
+	{Name: "min", Func: indirectChecker(min), Params: fixedParams(1, 1, ParamNumber), Kinds: numberKinds, Description: "Number must be at least the param."},
+	{Name: "max", Func: indirectChecker(max), Params: fixedParams(1, 1, ParamNumber), Kinds: numberKinds, Description: "Number must be at most the param."},
+	{Name: "minlen", Func: indirectChecker(minlen), Params: fixedParams(1, 1, ParamInt), Kinds: lengthKinds, Description: "Length must be at least the param; strings count runes."},
+	{Name: "maxlen", Func: indirectChecker(maxlen), Params: fixedParams(1, 1, ParamInt), Kinds: lengthKinds, Description: "Length must be at most the param; strings count runes."},
+	{Name: "alpha", Func: indirectChecker(alpha), Kinds: stringKinds, Description: "ASCII letters only."},
+	{Name: "alphanumeric", Func: indirectChecker(alphaNumeric), Kinds: stringKinds, Description: "ASCII letters and digits only."},
+	{Name: "alphadash", Func: indirectChecker(alphaDash), Kinds: stringKinds, Description: "Letters, digits and underscores only."},
+	{Name: "username", Func: indirectChecker(userName), Kinds: stringKinds, MessageKey: "alphadash", Description: "alphadash, starting with a letter and not ending with an underscore."},
+	{Name: "email", Func: indirectChecker(email), Kinds: stringKinds, Description: "Email address."},
+	{Name: "ipv4", Func: indirectChecker(ipv4), Kinds: stringKinds, Description: "IPv4 address."},
+	{Name: "mobile", Func: indirectChecker(mobile), Kinds: stringKinds, Description: "Chinese mobile phone number."},
+	{Name: "tel", Func: indirectChecker(tel), Kinds: stringKinds, Description: "Chinese landline number."},
+	{Name: "phone", Func: indirectChecker(phone), Kinds: stringKinds, Description: "Chinese mobile phone or landline number."},
+	{Name: "idcard", Func: indirectChecker(idCard), Kinds: stringKinds, Description: "Chinese ID card number."},
+	{Name: "equal", Func: indirectChecker(equal), Params: fixedParams(1, 1, ParamField), Description: "Stringified value must equal the referenced field's."},
+	{Name: "list", Func: indirectChecker(list), Params: variadicParams(1, ParamString), Description: "Stringified value must be one of the params."},
+
+	{Name: "required_if", Func: requiredWhen(fieldsEqual), Params: variadicParams(2, ParamField, ParamString), MessageKey: "required", Description: "required if every referenced field equals its value."},
+	{Name: "required_unless", Func: requiredWhen(negate(fieldsEqual)), Params: variadicParams(2, ParamField, ParamString), MessageKey: "required", Description: "required unless every referenced field equals its value."},
+	{Name: "required_with", Func: requiredWhen(anyFieldPresent), Params: variadicParams(1, ParamField), MessageKey: "required", Description: "required if any referenced field is present."},
+	{Name: "required_with_all", Func: requiredWhen(allFieldsPresent), Params: variadicParams(1, ParamField), MessageKey: "required", Description: "required if all referenced fields are present."},
+	{Name: "required_without", Func: requiredWhen(negate(allFieldsPresent)), Params: variadicParams(1, ParamField), MessageKey: "required", Description: "required if any referenced field is absent."},
+	{Name: "required_without_all", Func: requiredWhen(negate(anyFieldPresent)), Params: variadicParams(1, ParamField), MessageKey: "required", Description: "required if all referenced fields are absent."},
+	{Name: "excluded_if", Func: excludedWhen(fieldsEqual), Params: variadicParams(2, ParamField, ParamString), MessageKey: "excluded", Description: "Must be empty if every referenced field equals its value."},
+	{Name: "excluded_unless", Func: excludedWhen(negate(fieldsEqual)), Params: variadicParams(2, ParamField, ParamString), MessageKey: "excluded", Description: "Must be empty unless every referenced field equals its value."},
+	{Name: "excluded_with", Func: excludedWhen(anyFieldPresent), Params: variadicParams(1, ParamField), MessageKey: "excluded", Description: "Must be empty if any referenced field is present."},
+	{Name: "excluded_with_all", Func: excludedWhen(allFieldsPresent), Params: variadicParams(1, ParamField), MessageKey: "excluded", Description: "Must be empty if all referenced fields are present."},
+	{Name: "excluded_without", Func: excludedWhen(negate(allFieldsPresent)), Params: variadicParams(1, ParamField), MessageKey: "excluded", Description: "Must be empty if any referenced field is absent."},
+	{Name: "excluded_without_all", Func: excludedWhen(negate(anyFieldPresent)), Params: variadicParams(1, ParamField), MessageKey: "excluded", Description: "Must be empty if all referenced fields are absent."},
+
+	{Name: "eqfield", Func: indirectChecker(fieldComparer(func(cmp int) bool { return cmp == 0 }, false)), Params: fixedParams(1, 1, ParamField), Description: "Must equal the referenced field."},
+	{Name: "nefield", Func: indirectChecker(fieldComparer(func(cmp int) bool { return cmp != 0 }, false)), Params: fixedParams(1, 1, ParamField), Description: "Must differ from the referenced field."},
+	{Name: "gtfield", Func: indirectChecker(fieldComparer(func(cmp int) bool { return cmp > 0 }, true)), Params: fixedParams(1, 1, ParamField), Description: "Must be greater than the referenced field."},
+	{Name: "gtefield", Func: indirectChecker(fieldComparer(func(cmp int) bool { return cmp >= 0 }, true)), Params: fixedParams(1, 1, ParamField), Description: "Must be greater than or equal to the referenced field."},
+	{Name: "ltfield", Func: indirectChecker(fieldComparer(func(cmp int) bool { return cmp < 0 }, true)), Params: fixedParams(1, 1, ParamField), Description: "Must be less than the referenced field."},
+	{Name: "ltefield", Func: indirectChecker(fieldComparer(func(cmp int) bool { return cmp <= 0 }, true)), Params: fixedParams(1, 1, ParamField), Description: "Must be less than or equal to the referenced field."},
+
+	{Name: "between", Func: indirectChecker(between), Params: fixedParams(2, 2, ParamBound), Kinds: numberKinds, Description: "Number or time must lie within the two params, inclusive."},
+	{Name: "gt", Func: indirectChecker(gt), Params: fixedParams(1, 1, ParamNumber), Kinds: numberKinds, Description: "Number must be greater than the param."},
+	{Name: "lt", Func: indirectChecker(lt), Params: fixedParams(1, 1, ParamNumber), Kinds: numberKinds, Description: "Number must be less than the param."},
+	{Name: "multipleof", Func: indirectChecker(multipleOf), Params: fixedParams(1, 1, ParamNumber), Kinds: numberKinds, Description: "Number must be an integer multiple of the param."},
+	{Name: "positive", Func: indirectChecker(sign(func(cmp int) bool { return cmp > 0 })), Kinds: numberKinds, Description: "Number must be greater than zero."},
+	{Name: "negative", Func: indirectChecker(sign(func(cmp int) bool { return cmp < 0 })), Kinds: numberKinds, Description: "Number must be less than zero."},
+	{Name: "nonzero", Func: indirectChecker(sign(func(cmp int) bool { return cmp != 0 })), Kinds: numberKinds, Description: "Number must not be zero."},
+	{Name: "decimal", Func: indirectChecker(decimal), Params: fixedParams(2, 2, ParamInt), Kinds: numberKinds, Description: "Number must fit DECIMAL(precision, scale)."},
+
+	{Name: "regex", Func: indirectChecker(regex), Params: fixedParams(1, 1, ParamRegex), Kinds: stringKinds, Description: "Must match the regular expression."},
+	{Name: "pattern", Func: indirectChecker(pattern), Params: fixedParams(1, 1, ParamPattern), Kinds: stringKinds, Description: "Must match the pattern registered under the param."},
+
+	{Name: "before", Func: indirectChecker(before), Params: fixedParams(1, 1, ParamTime), Kinds: timeKinds, Description: "Time must be before the param."},
+	{Name: "after", Func: indirectChecker(after), Params: fixedParams(1, 1, ParamTime), Kinds: timeKinds, Description: "Time must be after the param."},
+	{Name: "datetime", Func: indirectChecker(datetime), Params: fixedParams(1, 1, ParamLayout), Kinds: stringKinds, Description: "Must be a time in the layout of the param."},
+	{Name: "weekday", Func: indirectChecker(weekday), Params: variadicParams(1, ParamWeekday), Kinds: timeKinds, Description: "Time must fall on one of the weekdays."},
+	{Name: "age", Func: indirectChecker(age), Params: fixedParams(1, 2, ParamInt), Kinds: timeKinds, Description: "Birthday must give an age within the params, the upper one optional."},
 }
 
 func required(c CheckerContext) *ErrContext {
-	errCtx := NewErrorContext(c)
+	if isEmpty(c.FieldValue, c.FieldType) {
+		return NewErrorContext(c)
+	}
+	return nil
+}
 
-	if c.FieldValue == nil {
-		return errCtx
+// omitEmpty never fails. The rule is evaluated by Check, which skips the
+// field's later rules when the value is empty.
+func omitEmpty(c CheckerContext) *ErrContext {
+	return nil
+}
+
+// emptyString is the result of a string checker for the empty string: it
+// is accepted unless StrictEmpty is set.
+func emptyString(c CheckerContext) *ErrContext {
+	if c.StrictEmpty {
+		return NewErrorContext(c)
+	}
+	return nil
+}
+
+// isEmpty reports whether the given field value is considered empty by
+// the required checker.
+func isEmpty(value interface{}, typ reflect.Type) bool {
+	if value == nil {
+		return true
+	}
+
+	// A NULL sql.Null* value (or any other driver.Valuer) is empty. The
+	// valuer is unwrapped only once, since its Value may return the valuer
+	// itself, and one whose Value fails is not empty.
+	if typ.Implements(valuerType) {
+		rv := reflect.ValueOf(value)
+		if rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return true
+		}
+		driverValue, err := value.(driver.Valuer).Value()
+		if err != nil {
+			return false
+		}
+		if driverValue == nil {
+			return true
+		}
+		return isEmptyPlain(driverValue, reflect.TypeOf(driverValue))
+	}
+	return isEmptyPlain(value, typ)
+}
+
+// isEmptyPlain is isEmpty for a value that isn't read through a
+// driver.Valuer.
+func isEmptyPlain(value interface{}, typ reflect.Type) bool {
+	// Leaf types such as time.Time, big.Int or UUIDs are empty when zero,
+	// whether or not they are comparable.
+	if isLeafType(typ) {
+		return isZeroLeaf(value)
 	}
 
 	// Length-aware kinds (slice/array/map/string/chan) are considered empty
 	// when their length is zero. Doing this before the zero-value comparison
 	// also avoids "comparing uncomparable type" panics for maps and slices.
-	switch c.FieldType.Kind() {
+	switch typ.Kind() {
 	case reflect.Slice, reflect.Array, reflect.Map, reflect.String, reflect.Chan:
-		if reflect.ValueOf(c.FieldValue).Len() == 0 {
-			return errCtx
-		}
-		return nil
+		return reflect.ValueOf(value).Len() == 0
 	case reflect.Ptr, reflect.Interface, reflect.Func:
-		if reflect.ValueOf(c.FieldValue).IsNil() {
-			return errCtx
-		}
-		return nil
+		return reflect.ValueOf(value).IsNil()
 	}
 
 	// Skip incomparable types to keep reflect.Value.Interface() == FieldValue
 	// from panicking.
-	if !c.FieldType.Comparable() {
-		return nil
+	if !typ.Comparable() {
+		return false
 	}
 
-	zeroValue := reflect.Zero(c.FieldType)
-	if zeroValue.Interface() == c.FieldValue {
-		return errCtx
-	}
-	return nil
+	zeroValue := reflect.Zero(typ)
+	return zeroValue.Interface() == value
 }
 
 func min(c CheckerContext) *ErrContext {
@@ -94,7 +319,6 @@ func max(c CheckerContext) *ErrContext {
 }
 
 func minOrMax(c CheckerContext, flag string) *ErrContext {
-	ctx := NewErrorContext(c)
 	if len(c.Rule.params) != 1 {
 		return MakeCheckerParamError(c)
 	}
@@ -103,73 +327,21 @@ func minOrMax(c CheckerContext, flag string) *ErrContext {
 		return MakeValueTypeError(c)
 	}
 
-	limitStr := c.Rule.params[0]
-
-	switch reflect.TypeOf(c.FieldValue).Kind() {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		limit, err := strconv.ParseInt(limitStr, 10, 64)
-		if err != nil {
-			return MakeCheckerParamError(c)
-		}
-		ctx.SetFieldLimitValue(limit)
-
-		value := reflect.ValueOf(c.FieldValue).Int()
-		if flag == "min" {
-			if value < limit {
-				return ctx
-			}
-		} else {
-			if value > limit {
-				return ctx
-			}
-		}
+	// As they always have, min and max ignore values that aren't numbers,
+	// such as strings that don't hold one.
+	if !isNumber(c) {
 		return nil
+	}
 
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		limit, err := strconv.ParseUint(limitStr, 10, 64)
-		if err != nil {
-			return MakeCheckerParamError(c)
-		}
-		ctx.SetFieldLimitValue(limit)
-
-		value := reflect.ValueOf(c.FieldValue).Uint()
-		if flag == "min" {
-			if value < limit {
-				return ctx
-			}
-		} else {
-			if value > limit {
-				return ctx
-			}
-		}
-		return nil
+	cmp, limit, ok := compareBound(c, c.Rule.params[0])
+	if !ok {
+		return MakeCheckerParamError(c)
+	}
 
-	case reflect.Float32, reflect.Float64:
-		limit, err := strconv.ParseFloat(limitStr, 64)
-		if err != nil {
-			return MakeCheckerParamError(c)
-		}
+	if (flag == "min" && cmp < 0) || (flag == "max" && cmp > 0) {
+		ctx := NewErrorContext(c)
 		ctx.SetFieldLimitValue(limit)
-
-		value64, ok := c.FieldValue.(float64)
-		if !ok {
-			value32, ok := c.FieldValue.(float32)
-			if !ok {
-				return MakeValueTypeError(c)
-			}
-			value64 = float64(value32)
-		}
-
-		if flag == "min" {
-			if value64 < limit {
-				return ctx
-			}
-		} else {
-			if value64 > limit {
-				return ctx
-			}
-		}
-		return nil
+		return ctx
 	}
 	return nil
 }
@@ -204,7 +376,7 @@ func minOrMaxLen(c CheckerContext, flag string) *ErrContext {
 		s := c.FieldValue.(string)
 		// Empty strings short-circuit to "no error" for parity with other
 		// string-aware checkers (alphaDash/email/...).
-		if s == "" {
+		if s == "" && !c.StrictEmpty {
 			return nil
 		}
 		length = utf8.RuneCountInString(s)
@@ -233,7 +405,7 @@ func alpha(c CheckerContext) *ErrContext {
 
 	value := c.FieldValue.(string)
 	if value == "" {
-		return nil
+		return emptyString(c)
 	}
 
 	ctx := NewErrorContext(c)
@@ -252,7 +424,7 @@ func alphaNumeric(c CheckerContext) *ErrContext {
 
 	value := c.FieldValue.(string)
 	if value == "" {
-		return nil
+		return emptyString(c)
 	}
 
 	ctx := NewErrorContext(c)
@@ -264,7 +436,8 @@ func alphaNumeric(c CheckerContext) *ErrContext {
 	return nil
 }
 
-var alphaDashPattern = regexp.MustCompile(`^\w+$`)
+// AlphaDashPattern is used to check alphadash and username values.
+var AlphaDashPattern = regexp.MustCompile(`^\w+$`)
 
 func alphaDash(c CheckerContext) *ErrContext {
 	if c.FieldValue == nil || reflect.TypeOf(c.FieldValue).Kind() != reflect.String {
@@ -272,10 +445,10 @@ func alphaDash(c CheckerContext) *ErrContext {
 	}
 	value := c.FieldValue.(string)
 	if value == "" {
-		return nil
+		return emptyString(c)
 	}
 
-	if !alphaDashPattern.MatchString(value) {
+	if !AlphaDashPattern.MatchString(value) {
 		return NewErrorContext(c)
 	}
 	return nil
@@ -287,13 +460,12 @@ func userName(c CheckerContext) *ErrContext {
 	}
 
 	value := c.FieldValue.(string)
-	if value == "" {
+	if value == "" && !c.StrictEmpty {
 		return nil
 	}
 
 	// is alpha dash.
 	if ctx := alphaDash(c); ctx != nil {
-		ctx.SetTemplate("alphadash")
 		return ctx
 	}
 
@@ -316,9 +488,9 @@ func userName(c CheckerContext) *ErrContext {
 	return nil
 }
 
-// emailPattern is compiled once on package init to avoid re-compiling on
-// every email() invocation.
-var emailPattern = regexp.MustCompile(`^[\w!#$%&'*+/=?^_` + "`" + `{|}~-]+(?:\.[\w!#$%&'*+/=?^_` + "`" + `{|}~-]+)*@(?:[\w](?:[\w-]*[\w])?\.)+[a-zA-Z0-9](?:[\w-]*[\w])?$`)
+// EmailPattern is used to check email addresses. It is compiled once on
+// package init to avoid re-compiling on every email() invocation.
+var EmailPattern = regexp.MustCompile(`^[\w!#$%&'*+/=?^_` + "`" + `{|}~-]+(?:\.[\w!#$%&'*+/=?^_` + "`" + `{|}~-]+)*@(?:[\w](?:[\w-]*[\w])?\.)+[a-zA-Z0-9](?:[\w-]*[\w])?$`)
 
 func email(c CheckerContext) *ErrContext {
 	if c.FieldValue == nil || reflect.TypeOf(c.FieldValue).Kind() != reflect.String {
@@ -327,15 +499,16 @@ func email(c CheckerContext) *ErrContext {
 
 	value := c.FieldValue.(string)
 	if value == "" {
-		return nil
+		return emptyString(c)
 	}
-	if !emailPattern.MatchString(value) {
+	if !EmailPattern.MatchString(value) {
 		return NewErrorContext(c)
 	}
 	return nil
 }
 
-var ipv4Pattern = regexp.MustCompile(`^((2[0-4]\d|25[0-5]|[01]?\d\d?)\.){3}(2[0-4]\d|25[0-5]|[01]?\d\d?)$`)
+// IPv4Pattern is used to check IPv4 addresses.
+var IPv4Pattern = regexp.MustCompile(`^((2[0-4]\d|25[0-5]|[01]?\d\d?)\.){3}(2[0-4]\d|25[0-5]|[01]?\d\d?)$`)
 
 func ipv4(c CheckerContext) *ErrContext {
 	if c.FieldValue == nil || reflect.TypeOf(c.FieldValue).Kind() != reflect.String {
@@ -344,9 +517,9 @@ func ipv4(c CheckerContext) *ErrContext {
 
 	value := c.FieldValue.(string)
 	if value == "" {
-		return nil
+		return emptyString(c)
 	}
-	if !ipv4Pattern.MatchString(value) {
+	if !IPv4Pattern.MatchString(value) {
 		return NewErrorContext(c)
 	}
 	return nil
@@ -363,7 +536,7 @@ func mobile(c CheckerContext) *ErrContext {
 
 	value := c.FieldValue.(string)
 	if value == "" {
-		return nil
+		return emptyString(c)
 	}
 	if !MobilePattern.MatchString(value) {
 		return NewErrorContext(c)
@@ -371,7 +544,8 @@ func mobile(c CheckerContext) *ErrContext {
 	return nil
 }
 
-var telPattern = regexp.MustCompile(`^(0\d{2,3}(-)?)?\d{7,8}$`)
+// TelPattern is used to check landline numbers.
+var TelPattern = regexp.MustCompile(`^(0\d{2,3}(-)?)?\d{7,8}$`)
 
 func tel(c CheckerContext) *ErrContext {
 	if c.FieldValue == nil || reflect.TypeOf(c.FieldValue).Kind() != reflect.String {
@@ -380,9 +554,9 @@ func tel(c CheckerContext) *ErrContext {
 
 	value := c.FieldValue.(string)
 	if value == "" {
-		return nil
+		return emptyString(c)
 	}
-	if !telPattern.MatchString(value) {
+	if !TelPattern.MatchString(value) {
 		return NewErrorContext(c)
 	}
 	return nil
@@ -401,7 +575,8 @@ func phone(c CheckerContext) *ErrContext {
 	return ctx
 }
 
-var idCardPattern = regexp.MustCompile(`(^\d{15}$)|(^\d{17}([0-9Xx])$)`)
+// IDCardPattern is used to check ID card numbers.
+var IDCardPattern = regexp.MustCompile(`(^\d{15}$)|(^\d{17}([0-9Xx])$)`)
 
 func idCard(c CheckerContext) *ErrContext {
 	if c.FieldValue == nil || reflect.TypeOf(c.FieldValue).Kind() != reflect.String {
@@ -410,9 +585,9 @@ func idCard(c CheckerContext) *ErrContext {
 
 	value := c.FieldValue.(string)
 	if value == "" {
-		return nil
+		return emptyString(c)
 	}
-	if !idCardPattern.MatchString(value) {
+	if !IDCardPattern.MatchString(value) {
 		return NewErrorContext(c)
 	}
 	return nil
@@ -426,25 +601,16 @@ func equal(c CheckerContext) *ErrContext {
 	// equal needs the surrounding struct to compare another field. If a
 	// caller invokes the checker directly with a zero StructValue (or a
 	// non-struct value), there's nothing to compare to.
-	if !c.StructValue.IsValid() || c.StructValue.Kind() != reflect.Struct {
+	equalFieldValue, _, ok := c.LookupField(c.Rule.params[0])
+	if !ok {
 		return MakeFieldNotFoundError(c)
 	}
 
-	ctx := NewErrorContext(c)
 	value := fmt.Sprintf("%v", c.FieldValue)
-	equalField := c.Rule.params[0]
-
-	structType := c.StructValue.Type()
-	for i := 0; i < structType.NumField(); i++ {
-		if structType.Field(i).Name == equalField {
-			equalFieldValue := fmt.Sprintf("%v", c.StructValue.Field(i).Interface())
-			if value != equalFieldValue {
-				return ctx
-			}
-			return nil
-		}
+	if value != valueString(equalFieldValue) {
+		return NewErrorContext(c)
 	}
-	return MakeFieldNotFoundError(c)
+	return nil
 }
 
 func list(c CheckerContext) *ErrContext {
@@ -0,0 +1,165 @@
+package govalid
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// fieldComparer returns a checker that compares the field against the
+// field referenced by the rule's single param. accept decides from the
+// comparison result, which is negative, zero or positive when the field is
+// less than, equal to or greater than the other field. When ordered is
+// false, values that can't be ordered are still compared for equality.
+func fieldComparer(accept func(cmp int) bool, ordered bool) CheckFunc {
+	return func(c CheckerContext) *ErrContext {
+		if len(c.Rule.params) != 1 || c.Rule.params[0] == "" {
+			return MakeCheckerParamError(c)
+		}
+
+		otherValue, otherOwner, otherField, ok := c.lookupField(c.Rule.params[0])
+		if !ok {
+			return MakeFieldNotFoundError(c)
+		}
+		if c.FieldValue == nil {
+			return MakeValueTypeError(c)
+		}
+		value := reflect.ValueOf(c.FieldValue)
+
+		// There's nothing to compare to when the other field is absent,
+		// e.g. a nil pointer; pair the rule with required_* if needed.
+		otherValue = indirect(otherValue)
+		if !otherValue.IsValid() {
+			return nil
+		}
+
+		cmp, ok := compareValues(value, otherValue)
+		if !ok {
+			if ordered || value.Type() != otherValue.Type() {
+				return MakeValueTypeError(c)
+			}
+			cmp = 1
+			if reflect.DeepEqual(value.Interface(), otherValue.Interface()) {
+				cmp = 0
+			}
+		}
+
+		if accept(cmp) {
+			return nil
+		}
+		ctx := NewErrorContext(c)
+		ctx.SetFieldLimitValue(fieldLabel(otherOwner, otherField, c.TemplateLanguage))
+		return ctx
+	}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// compareValues compares two values of the same family by type: numbers
+// of any int, uint or float kind (including time.Duration), strings, and
+// time.Time. Both values are indirected first (see indirectValue). It
+// returns false if the values can't be ordered against each other.
+func compareValues(a, b reflect.Value) (int, bool) {
+	a, b = indirect(a), indirect(b)
+	if !a.IsValid() || !b.IsValid() {
+		return 0, false
+	}
+
+	if a.Type() == timeType && b.Type() == timeType {
+		at, bt := a.Interface().(time.Time), b.Interface().(time.Time)
+		switch {
+		case at.Before(bt):
+			return -1, true
+		case at.After(bt):
+			return 1, true
+		}
+		return 0, true
+	}
+
+	switch {
+	case a.Kind() == reflect.String && b.Kind() == reflect.String:
+		return strings.Compare(a.String(), b.String()), true
+	case isIntKind(a.Kind()) && isIntKind(b.Kind()):
+		return compareInt64(a.Int(), b.Int()), true
+	case isUintKind(a.Kind()) && isUintKind(b.Kind()):
+		return compareUint64(a.Uint(), b.Uint()), true
+	case isIntKind(a.Kind()) && isUintKind(b.Kind()):
+		if a.Int() < 0 {
+			return -1, true
+		}
+		return compareUint64(uint64(a.Int()), b.Uint()), true
+	case isUintKind(a.Kind()) && isIntKind(b.Kind()):
+		if b.Int() < 0 {
+			return 1, true
+		}
+		return compareUint64(a.Uint(), uint64(b.Int())), true
+	case isNumberKind(a.Kind()) && isNumberKind(b.Kind()):
+		return compareFloat64(toFloat64(a), toFloat64(b)), true
+	}
+	return 0, false
+}
+
+func isIntKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isUintKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+func isNumberKind(k reflect.Kind) bool {
+	return isIntKind(k) || isUintKind(k) || isFloatKind(k)
+}
+
+// toFloat64 converts a value of any number kind to float64.
+func toFloat64(v reflect.Value) float64 {
+	switch {
+	case isIntKind(v.Kind()):
+		return float64(v.Int())
+	case isUintKind(v.Kind()):
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareUint64(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloat64(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
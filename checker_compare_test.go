@@ -0,0 +1,145 @@
+package govalid
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func Test_gtfield_Time(t *testing.T) {
+	type event struct {
+		StartAt time.Time `label:"开始时间" label-en:"start"`
+		EndAt   time.Time `valid:"gtfield:StartAt" label:"结束时间" label-en:"end"`
+	}
+
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("after", func(t *testing.T) {
+		_, ok := Check(event{StartAt: start, EndAt: start.Add(time.Hour)})
+		assert.True(t, ok)
+	})
+
+	t.Run("equal instant in another zone is not after", func(t *testing.T) {
+		shanghai := time.FixedZone("CST", 8*3600)
+		errs, ok := Check(event{StartAt: start, EndAt: start.In(shanghai)})
+		assert.False(t, ok)
+		assert.Equal(t, "结束时间应大于开始时间", errs[0].Error())
+	})
+
+	t.Run("english names both labels", func(t *testing.T) {
+		errs, _ := Check(event{StartAt: start, EndAt: start.Add(-time.Hour)}, language.English)
+		assert.Equal(t, "end should be greater than start", errs[0].Error())
+	})
+}
+
+func Test_gtefield_Numbers(t *testing.T) {
+	type filter struct {
+		MinPrice float64 `label:"最低价"`
+		MaxPrice int     `valid:"gtefield:MinPrice" label:"最高价"`
+	}
+
+	_, ok := Check(filter{MinPrice: 1.0, MaxPrice: 1})
+	assert.True(t, ok)
+
+	errs, ok := Check(filter{MinPrice: 1.5, MaxPrice: 1})
+	assert.False(t, ok)
+	assert.Equal(t, "最高价应大于或等于最低价", errs[0].Error())
+
+	t.Run("int against uint", func(t *testing.T) {
+		v := struct {
+			A uint64
+			B int8 `valid:"ltfield:A"`
+		}{A: 1 << 63, B: -1}
+		_, ok := Check(v)
+		assert.True(t, ok)
+	})
+}
+
+func Test_ltfield_Durations(t *testing.T) {
+	v := struct {
+		Timeout time.Duration
+		Retry   time.Duration `valid:"ltefield:Timeout" label:"重试间隔"`
+	}{Timeout: time.Second, Retry: 2 * time.Second}
+	errs, ok := Check(v)
+	assert.False(t, ok)
+	assert.Equal(t, "重试间隔应小于或等于Timeout", errs[0].Error())
+}
+
+func Test_eqfield_nefield(t *testing.T) {
+	t.Run("float and int compare by value", func(t *testing.T) {
+		v := struct {
+			A float64
+			B int `valid:"eqfield:A"`
+		}{A: 1.0, B: 1}
+		_, ok := Check(v)
+		assert.True(t, ok)
+	})
+
+	t.Run("strings", func(t *testing.T) {
+		v := struct {
+			Password string `label:"密码"`
+			Old      string `valid:"nefield:Password" label:"旧密码"`
+		}{Password: "a", Old: "a"}
+		errs, ok := Check(v)
+		assert.False(t, ok)
+		assert.Equal(t, "旧密码不能等于密码", errs[0].Error())
+	})
+
+	t.Run("unordered values of the same type", func(t *testing.T) {
+		v := struct {
+			A bool
+			B bool `valid:"eqfield:A"`
+		}{A: true, B: true}
+		_, ok := Check(v)
+		assert.True(t, ok)
+	})
+
+	t.Run("pointers are dereferenced", func(t *testing.T) {
+		one, two := 1, 2
+		v := struct {
+			A *int
+			B *int `valid:"eqfield:A"`
+		}{A: &one, B: &two}
+		_, ok := Check(v)
+		assert.False(t, ok)
+	})
+}
+
+func Test_fieldComparer_Errors(t *testing.T) {
+	t.Run("unordered kind is type error", func(t *testing.T) {
+		v := struct {
+			A bool
+			B bool `valid:"gtfield:A"`
+		}{}
+		errs, ok := Check(v)
+		assert.False(t, ok)
+		assert.Contains(t, errs[0].Error(), "参数类型不正确")
+	})
+
+	t.Run("mixed families are type error", func(t *testing.T) {
+		v := struct {
+			A string
+			B int `valid:"eqfield:A"`
+		}{}
+		errs, _ := Check(v)
+		assert.Contains(t, errs[0].Error(), "参数类型不正确")
+	})
+
+	t.Run("missing field", func(t *testing.T) {
+		v := struct {
+			B int `valid:"gtfield:Nope"`
+		}{}
+		errs, _ := Check(v)
+		assert.Equal(t, "字段不存在", errs[0].Error())
+	})
+
+	t.Run("missing param", func(t *testing.T) {
+		v := struct {
+			B int `valid:"gtfield"`
+		}{}
+		errs, _ := Check(v)
+		assert.Contains(t, errs[0].Error(), "检查规则入参错误")
+	})
+}
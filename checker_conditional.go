@@ -0,0 +1,128 @@
+package govalid
+
+import "reflect"
+
+// fieldCondition evaluates the condition of a conditional checker against
+// the fields referenced by the rule's params. It returns a non-nil error
+// context when the params are malformed or reference a missing field.
+type fieldCondition func(c CheckerContext) (bool, *ErrContext)
+
+// requiredWhen returns a checker that requires the field to be non-empty
+// when the condition holds.
+func requiredWhen(condition fieldCondition) CheckFunc {
+	return func(c CheckerContext) *ErrContext {
+		met, errCtx := condition(c)
+		if errCtx != nil {
+			return errCtx
+		}
+		if !met || !isEmpty(c.FieldValue, c.FieldType) {
+			return nil
+		}
+
+		return NewErrorContext(c)
+	}
+}
+
+// excludedWhen returns a checker that requires the field to be empty when
+// the condition holds.
+func excludedWhen(condition fieldCondition) CheckFunc {
+	return func(c CheckerContext) *ErrContext {
+		met, errCtx := condition(c)
+		if errCtx != nil {
+			return errCtx
+		}
+		if !met || isEmpty(c.FieldValue, c.FieldType) {
+			return nil
+		}
+
+		return NewErrorContext(c)
+	}
+}
+
+// negate negates the given condition.
+func negate(condition fieldCondition) fieldCondition {
+	return func(c CheckerContext) (bool, *ErrContext) {
+		met, errCtx := condition(c)
+		return !met, errCtx
+	}
+}
+
+// fieldsEqual holds when every `Field,value` pair of the params matches,
+// comparing the stringified field value like equal and list do.
+// e.g. `valid:"required_if:InvoiceType,company"`
+func fieldsEqual(c CheckerContext) (bool, *ErrContext) {
+	params := c.Rule.params
+	if len(params) == 0 || len(params)%2 != 0 {
+		return false, MakeCheckerParamError(c)
+	}
+
+	for i := 0; i < len(params); i += 2 {
+		if params[i] == "" {
+			return false, MakeCheckerParamError(c)
+		}
+		value, _, ok := c.LookupField(params[i])
+		if !ok {
+			return false, MakeFieldNotFoundError(c)
+		}
+		if valueString(value) != params[i+1] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// anyFieldPresent holds when at least one of the fields named by the params
+// is non-empty.
+// e.g. `valid:"required_with:Email,Phone"`
+func anyFieldPresent(c CheckerContext) (bool, *ErrContext) {
+	present, errCtx := fieldsPresent(c)
+	if errCtx != nil {
+		return false, errCtx
+	}
+	for _, p := range present {
+		if p {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// allFieldsPresent holds when every field named by the params is non-empty.
+func allFieldsPresent(c CheckerContext) (bool, *ErrContext) {
+	present, errCtx := fieldsPresent(c)
+	if errCtx != nil {
+		return false, errCtx
+	}
+	for _, p := range present {
+		if !p {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// fieldsPresent reports for each field named by the params whether it is
+// non-empty.
+func fieldsPresent(c CheckerContext) ([]bool, *ErrContext) {
+	if len(c.Rule.params) == 0 {
+		return nil, MakeCheckerParamError(c)
+	}
+
+	present := make([]bool, 0, len(c.Rule.params))
+	for _, name := range c.Rule.params {
+		if name == "" {
+			return nil, MakeCheckerParamError(c)
+		}
+		value, _, ok := c.LookupField(name)
+		if !ok {
+			return nil, MakeFieldNotFoundError(c)
+		}
+		present = append(present, !isEmptyValue(value))
+	}
+	return present, nil
+}
+
+// isEmptyValue is isEmpty for a reflect.Value.
+func isEmptyValue(value reflect.Value) bool {
+	return isEmpty(value.Interface(), value.Type())
+}
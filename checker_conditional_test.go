@@ -0,0 +1,200 @@
+package govalid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+type checkoutForm struct {
+	InvoiceType string `label:"发票类型"`
+	CompanyName string `valid:"required_if:InvoiceType,company" label:"公司名称" label-en:"Company name"`
+	Email       string `label:"邮箱"`
+	Phone       string `valid:"required_without:Email" label:"手机号"`
+}
+
+func Test_requiredIf(t *testing.T) {
+	t.Run("condition met and empty", func(t *testing.T) {
+		errs, ok := Check(checkoutForm{InvoiceType: "company", Email: "a@b.c"})
+		assert.False(t, ok)
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "公司名称不能为空", errs[0].Error())
+	})
+
+	t.Run("condition met and present", func(t *testing.T) {
+		_, ok := Check(checkoutForm{InvoiceType: "company", CompanyName: "e99", Email: "a@b.c"})
+		assert.True(t, ok)
+	})
+
+	t.Run("condition not met", func(t *testing.T) {
+		_, ok := Check(checkoutForm{InvoiceType: "personal", Email: "a@b.c"})
+		assert.True(t, ok)
+	})
+
+	t.Run("english", func(t *testing.T) {
+		errs, _ := Check(checkoutForm{InvoiceType: "company", Email: "a@b.c"}, language.English)
+		assert.Equal(t, "Company name can not be empty", errs[0].Error())
+	})
+
+	t.Run("multiple pairs must all match", func(t *testing.T) {
+		v := struct {
+			A string
+			B int
+			C string `valid:"required_if:A,x,B,1"`
+		}{A: "x", B: 2}
+		_, ok := Check(v)
+		assert.True(t, ok)
+
+		v.B = 1
+		_, ok = Check(v)
+		assert.False(t, ok)
+	})
+}
+
+func Test_requiredUnless(t *testing.T) {
+	v := struct {
+		Role   string
+		Reason string `valid:"required_unless:Role,admin" label:"理由"`
+	}{Role: "admin"}
+	_, ok := Check(v)
+	assert.True(t, ok)
+
+	v.Role = "guest"
+	errs, ok := Check(v)
+	assert.False(t, ok)
+	assert.Equal(t, "理由不能为空", errs[0].Error())
+}
+
+func Test_requiredWith(t *testing.T) {
+	type form struct {
+		Street  string
+		City    string
+		Zip     string `valid:"required_with:Street,City" label:"邮编"`
+		Country string `valid:"required_with_all:Street,City" label:"国家"`
+	}
+
+	_, ok := Check(form{})
+	assert.True(t, ok)
+
+	errs, ok := Check(form{Street: "x"})
+	assert.False(t, ok)
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "邮编不能为空", errs[0].Error())
+
+	errs, ok = Check(form{Street: "x", City: "y"})
+	assert.False(t, ok)
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "国家不能为空", errs[1].Error())
+}
+
+func Test_requiredWithout(t *testing.T) {
+	t.Run("required_without", func(t *testing.T) {
+		errs, ok := Check(checkoutForm{})
+		assert.False(t, ok)
+		assert.Equal(t, "手机号不能为空", errs[0].Error())
+
+		_, ok = Check(checkoutForm{Email: "a@b.c"})
+		assert.True(t, ok)
+	})
+
+	t.Run("required_without_all", func(t *testing.T) {
+		type form struct {
+			Email  string
+			Phone  string
+			WeChat string `valid:"required_without_all:Email,Phone" label:"微信"`
+		}
+		_, ok := Check(form{Phone: "1"})
+		assert.True(t, ok)
+
+		errs, ok := Check(form{})
+		assert.False(t, ok)
+		assert.Equal(t, "微信不能为空", errs[0].Error())
+	})
+}
+
+func Test_excluded(t *testing.T) {
+	type form struct {
+		InvoiceType string
+		Email       string
+		Phone       string
+		TaxID       string `valid:"excluded_if:InvoiceType,personal" label:"税号" label-en:"Tax ID"`
+		Fax         string `valid:"excluded_unless:InvoiceType,company" label:"传真"`
+		Backup      string `valid:"excluded_with:Email" label:"备用"`
+		Pager       string `valid:"excluded_with_all:Email,Phone" label:"寻呼机"`
+		Note        string `valid:"excluded_without:Email" label:"备注"`
+		Memo        string `valid:"excluded_without_all:Email,Phone" label:"便签"`
+	}
+
+	t.Run("all empty", func(t *testing.T) {
+		_, ok := Check(form{})
+		assert.True(t, ok)
+	})
+
+	t.Run("excluded_if", func(t *testing.T) {
+		errs, ok := Check(form{InvoiceType: "personal", TaxID: "1", Fax: "1"})
+		assert.False(t, ok)
+		assert.Equal(t, 2, len(errs))
+		assert.Equal(t, "税号必须为空", errs[0].Error())
+		assert.Equal(t, "传真必须为空", errs[1].Error())
+
+		errs, _ = Check(form{InvoiceType: "personal", TaxID: "1"}, language.English)
+		assert.Equal(t, "Tax ID must be empty", errs[0].Error())
+	})
+
+	t.Run("with and without", func(t *testing.T) {
+		errs, ok := Check(form{Email: "a", Phone: "b", Backup: "x", Pager: "x"})
+		assert.False(t, ok)
+		assert.Equal(t, 2, len(errs))
+
+		errs, ok = Check(form{Note: "x", Memo: "x"})
+		assert.False(t, ok)
+		assert.Equal(t, 2, len(errs))
+
+		_, ok = Check(form{Email: "a", Note: "x", Memo: "x"})
+		assert.True(t, ok)
+	})
+}
+
+func Test_conditional_ParamErrors(t *testing.T) {
+	t.Run("odd pair count", func(t *testing.T) {
+		v := struct {
+			A string
+			B string `valid:"required_if:A"`
+		}{}
+		errs, ok := Check(v)
+		assert.False(t, ok)
+		assert.Contains(t, errs[0].Error(), "检查规则入参错误")
+	})
+
+	t.Run("missing params", func(t *testing.T) {
+		v := struct {
+			B string `valid:"required_with"`
+		}{}
+		errs, ok := Check(v)
+		assert.False(t, ok)
+		assert.Contains(t, errs[0].Error(), "检查规则入参错误")
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		v := struct {
+			B string `valid:"required_without:Nope"`
+		}{}
+		errs, ok := Check(v)
+		assert.False(t, ok)
+		assert.Equal(t, "字段不存在", errs[0].Error())
+	})
+
+	t.Run("references resolve through parents", func(t *testing.T) {
+		type line struct {
+			Discount string `valid:"required_if:$root.Promo,yes" label:"折扣"`
+		}
+		v := struct {
+			Promo string
+			Lines []line
+		}{Promo: "yes", Lines: []line{{}}}
+		errs, ok := Check(v)
+		assert.False(t, ok)
+		assert.Equal(t, "折扣不能为空", errs[0].Error())
+	})
+}
@@ -1,6 +1,7 @@
 package govalid
 
 import (
+	"context"
 	"math"
 	"testing"
 
@@ -770,3 +771,245 @@ func Test_required_Extra(t *testing.T) {
 		assert.True(t, ok)
 	})
 }
+
+// =============================================================================
+// CheckerContext — Parent, Root, FieldPath and Index
+// =============================================================================
+
+func Test_CheckerContext_Position(t *testing.T) {
+	const checkerName = "recordPosition"
+	defer delete(Checkers, checkerName)
+
+	var got []CheckerContext
+	Checkers[checkerName] = func(c CheckerContext) *ErrContext {
+		got = append(got, c)
+		return nil
+	}
+
+	type item struct {
+		Price int `valid:"recordPosition"`
+	}
+	type order struct {
+		Currency string `valid:"recordPosition"`
+		Items    []item
+	}
+
+	v := order{Currency: "CNY", Items: []item{{Price: 1}, {Price: 2}}}
+	_, ok := Check(&v)
+	assert.True(t, ok)
+	assert.Equal(t, 3, len(got))
+
+	assert.Equal(t, "Currency", got[0].FieldPath)
+	assert.Equal(t, -1, got[0].Index)
+	assert.False(t, got[0].Parent.IsValid())
+
+	assert.Equal(t, "Items[1].Price", got[2].FieldPath)
+	assert.Equal(t, 1, got[2].Index)
+	assert.Equal(t, 2, got[2].StructValue.Interface().(item).Price)
+	assert.Equal(t, "CNY", got[2].Parent.Interface().(order).Currency)
+	assert.Equal(t, "CNY", got[2].Root.Interface().(order).Currency)
+
+	t.Run("root slice", func(t *testing.T) {
+		got = nil
+		_, _ = Check([]item{{Price: 1}, {Price: 2}})
+		assert.Equal(t, "[1].Price", got[1].FieldPath)
+		assert.Equal(t, 1, got[1].Index)
+	})
+}
+
+func Test_ErrContext_FieldPath(t *testing.T) {
+	type address struct {
+		City string `valid:"required"`
+	}
+	v := struct {
+		Address address
+	}{}
+	errs, ok := Check(v)
+	assert.False(t, ok)
+	assert.Equal(t, "Address.City", errs[0].FieldPath)
+}
+
+func Test_equal_FieldReferences(t *testing.T) {
+	type item struct {
+		Currency string `valid:"equal:../Currency"`
+		Region   string `valid:"equal:$root.Region"`
+	}
+	type shipment struct {
+		Items []item
+	}
+	type order struct {
+		Currency string
+		Region   string
+		Shipment shipment
+		Items    []item
+	}
+
+	t.Run("parent and root references", func(t *testing.T) {
+		v := order{
+			Currency: "CNY",
+			Region:   "CN",
+			Items:    []item{{Currency: "CNY", Region: "CN"}, {Currency: "USD", Region: "US"}},
+		}
+		errs, ok := Check(v)
+		assert.False(t, ok)
+		assert.Equal(t, 2, len(errs))
+		assert.Equal(t, "Items[1].Currency", errs[0].FieldPath)
+		assert.Equal(t, "Items[1].Region", errs[1].FieldPath)
+	})
+
+	t.Run("parent is the enclosing struct, not the root", func(t *testing.T) {
+		v := order{
+			Currency: "CNY",
+			Region:   "CN",
+			Shipment: shipment{Items: []item{{Currency: "CNY", Region: "CN"}}},
+		}
+		errs, ok := Check(v)
+		assert.False(t, ok)
+		// ../Currency resolves against Shipment, which has no Currency.
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "字段不存在", errs[0].Error())
+	})
+
+	t.Run("dotted path into nested struct", func(t *testing.T) {
+		type address struct{ City string }
+		v := struct {
+			City    string `valid:"equal:Address.City"`
+			Address address
+		}{City: "Wuhan", Address: address{City: "Beijing"}}
+		_, ok := Check(v)
+		assert.False(t, ok)
+	})
+
+	t.Run("embedded structs resolve against the struct embedding them", func(t *testing.T) {
+		type Credentials struct {
+			Password string
+			Confirm  string `valid:"equal:Password"`
+			Echo     string `valid:"equal:Name"`
+		}
+		v := struct {
+			Name string
+			Credentials
+		}{Name: "bob", Credentials: Credentials{Password: "a", Confirm: "a", Echo: "bob"}}
+		_, ok := Check(v)
+		assert.True(t, ok)
+
+		v.Confirm = "b"
+		errs, _ := Check(v)
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "Credentials.Confirm", errs[0].FieldPath)
+	})
+
+	t.Run("nested structs fall back to the enclosing structs", func(t *testing.T) {
+		type confirm struct {
+			Repeat string `valid:"equal:Password"`
+		}
+		type form struct {
+			Password string
+			Confirm  confirm
+			Confirms []confirm
+		}
+		_, ok := Check(form{Password: "a", Confirm: confirm{"a"}, Confirms: []confirm{{"a"}}})
+		assert.True(t, ok)
+
+		errs, _ := Check(form{Password: "a", Confirm: confirm{"b"}, Confirms: []confirm{{"b"}}})
+		assert.Equal(t, 2, len(errs))
+		assert.Equal(t, "Confirm.Repeat", errs[0].FieldPath)
+		assert.Equal(t, "Confirms[0].Repeat", errs[1].FieldPath)
+	})
+
+	t.Run("root of a checked slice is its element", func(t *testing.T) {
+		type line struct {
+			Currency string
+			Region   string
+			Items    []item
+		}
+		v := []line{
+			{Region: "CN", Items: []item{{Region: "CN"}}},
+			{Region: "US", Items: []item{{Region: "US"}, {Region: "CN"}}},
+		}
+		errs, ok := Check(v)
+		assert.False(t, ok)
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "[1].Items[1].Region", errs[0].FieldPath)
+	})
+
+	t.Run("parent of the root does not exist", func(t *testing.T) {
+		v := struct {
+			A string `valid:"equal:../A"`
+		}{A: "x"}
+		errs, ok := Check(v)
+		assert.False(t, ok)
+		assert.Equal(t, "字段不存在", errs[0].Error())
+	})
+}
+
+// =============================================================================
+// omitempty / StrictEmpty
+// =============================================================================
+
+func Test_OmitEmpty(t *testing.T) {
+	type form struct {
+		Age   int      `valid:"omitempty;min:1" label:"年龄"`
+		Role  string   `valid:"omitempty;list:admin,user" label:"角色"`
+		Tags  []string `valid:"omitempty;minlen:2" label:"标签"`
+		Score *int     `valid:"omitempty;required" label:"评分"`
+	}
+
+	t.Run("empty values skip later rules", func(t *testing.T) {
+		_, ok := Check(form{})
+		assert.True(t, ok)
+	})
+
+	t.Run("non-empty values are checked", func(t *testing.T) {
+		errs, ok := Check(form{Age: -1, Role: "guest", Tags: []string{"a"}})
+		assert.False(t, ok)
+		assert.Equal(t, 3, len(errs))
+		assert.Equal(t, "年龄应大于1", errs[0].Error())
+		assert.Equal(t, "角色不是一个有效的值", errs[1].Error())
+		assert.Equal(t, "标签长度应大于2", errs[2].Error())
+	})
+
+	t.Run("rules before omitempty still run", func(t *testing.T) {
+		v := struct {
+			Name string `valid:"required;omitempty;email" label:"邮箱"`
+		}{}
+		errs, ok := Check(v)
+		assert.False(t, ok)
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "邮箱不能为空", errs[0].Error())
+	})
+}
+
+func Test_StrictEmpty(t *testing.T) {
+	type form struct {
+		Email    string `valid:"email" label:"邮箱"`
+		Nickname string `valid:"alpha" label:"昵称"`
+		Username string `valid:"username" label:"用户名"`
+		Bio      string `valid:"minlen:2" label:"简介"`
+		Phone    string `valid:"omitempty;phone" label:"电话"`
+	}
+
+	t.Run("lenient by default", func(t *testing.T) {
+		_, ok := Check(form{})
+		assert.True(t, ok)
+	})
+
+	t.Run("strict rejects the empty string", func(t *testing.T) {
+		StrictEmpty = true
+		defer func() { StrictEmpty = false }()
+
+		errs, ok := Check(form{})
+		assert.False(t, ok)
+		assert.Equal(t, 4, len(errs))
+		assert.Equal(t, "邮箱不是合法的电子邮箱格式", errs[0].Error())
+		assert.Equal(t, "昵称必须只包含字母", errs[1].Error())
+		assert.Equal(t, "用户名只含有数字或字母以及下划线", errs[2].Error())
+		assert.Equal(t, "简介长度应大于2", errs[3].Error())
+	})
+
+	t.Run("per-call option", func(t *testing.T) {
+		errs, err := CheckContext(context.Background(), form{}, WithStrictEmpty(true))
+		assert.NoError(t, err)
+		assert.Equal(t, 4, len(errs))
+	})
+}
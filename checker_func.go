@@ -0,0 +1,264 @@
+package govalid
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// RegisterFunc registers a checker that accepts a field if fn returns true
+// for its value. fn is a func(v T) bool: the field value is converted to
+// T, and fields that can't be converted get the value type error. Integers
+// convert to the number types they fit in, floats to float types, and
+// named types to and from their underlying type. Like for the built-in
+// string checkers, empty strings are accepted unless StrictEmpty is set.
+//
+// messages are the checker's templates, keyed by language, and may be nil.
+//
+// Example:
+//
+//	govalid.RegisterFunc("even", func(v int64) bool { return v%2 == 0 },
+//		map[language.Tag]string{
+//			language.Chinese: "应为偶数",
+//			language.English: " should be even",
+//		})
+func RegisterFunc(name string, fn interface{}, messages map[language.Tag]string) error {
+	return registerFunc(name, fn, false, messages)
+}
+
+// RegisterParamFunc is RegisterFunc for a checker with params. fn is a
+// func(v T, p1 P1, ...) bool taking the field value and one argument per
+// rule param, or a variadic func(v T, p ...P) bool for any number of
+// params. Params are parsed as the type of their argument: strings, bools,
+// integers, floats, time.Duration ("30s") and time.Time (see the before
+// checker). A rule whose params don't parse gets the param error.
+//
+// The params, joined by ", ", are the limit of the checker's errors.
+//
+// Example:
+//
+//	govalid.RegisterParamFunc("range", func(v, lo, hi float64) bool { return lo <= v && v <= hi },
+//		map[language.Tag]string{
+//			language.Chinese: "应在{limit}之间}}",
+//			language.English: " should be between {limit}}}",
+//		})
+func RegisterParamFunc(name string, fn interface{}, messages map[language.Tag]string) error {
+	return registerFunc(name, fn, true, messages)
+}
+
+func registerFunc(name string, fn interface{}, withParams bool, messages map[language.Tag]string) error {
+	fnValue := reflect.ValueOf(fn)
+	if fnValue.Kind() != reflect.Func || fnValue.IsNil() {
+		return fmt.Errorf("register checker %q: %T is not a function", name, fn)
+	}
+	fnType := fnValue.Type()
+	if fnType.NumOut() != 1 || fnType.Out(0).Kind() != reflect.Bool {
+		return fmt.Errorf("register checker %q: %s must return a bool", name, fnType)
+	}
+	switch {
+	case fnType.NumIn() == 0 || fnType.IsVariadic() && fnType.NumIn() == 1:
+		return fmt.Errorf("register checker %q: %s must take the field value", name, fnType)
+	case !withParams && fnType.NumIn() > 1:
+		return fmt.Errorf("register checker %q: %s takes params, use RegisterParamFunc", name, fnType)
+	case withParams && fnType.NumIn() == 1:
+		return fmt.Errorf("register checker %q: %s takes no params, use RegisterFunc", name, fnType)
+	}
+
+	params := ParamSchema{Min: fnType.NumIn() - 1, Max: fnType.NumIn() - 1}
+	var args []reflect.Type
+	for i := 1; i < fnType.NumIn(); i++ {
+		argType := funcParamType(fnType, i)
+		paramType, ok := paramTypeOf(argType)
+		if !ok {
+			return fmt.Errorf("register checker %q: unsupported param type %s", name, argType)
+		}
+		params.Types = append(params.Types, paramType)
+		args = append(args, argType)
+	}
+	if fnType.IsVariadic() {
+		// Compile checks variadic params in repetitions of Types, so they
+		// must all be of one type.
+		for _, paramType := range params.Types {
+			if paramType != params.Types[0] {
+				return fmt.Errorf("register checker %q: params of variadic %s must have the same type", name, fnType)
+			}
+		}
+		params = ParamSchema{Min: params.Min - 1, Variadic: true, Types: params.Types[:1]}
+		args = args[:1]
+	}
+
+	RegisterChecker(CheckerSpec{
+		Name:   name,
+		Func:   indirectChecker(funcChecker(fnValue)),
+		Params: params,
+		Kinds:  funcKinds(fnType.In(0)),
+		args:   args,
+	})
+	setCheckerTemplates(name, messages)
+	return nil
+}
+
+// funcParamType returns the type of the argument for the rule param of fn
+// at argument index i, which is the element type for variadic arguments.
+func funcParamType(fnType reflect.Type, i int) reflect.Type {
+	last := fnType.NumIn() - 1
+	if fnType.IsVariadic() && i >= last {
+		return fnType.In(last).Elem()
+	}
+	return fnType.In(i)
+}
+
+// paramTypeOf returns the ParamType of params parsed as t. ok is false
+// if params can't be parsed as t.
+func paramTypeOf(t reflect.Type) (paramType ParamType, ok bool) {
+	switch {
+	case t == durationType:
+		return ParamDuration, true
+	case t == timeType:
+		return ParamTime, true
+	case t.Kind() == reflect.String:
+		return ParamString, true
+	case t.Kind() == reflect.Bool:
+		return ParamBool, true
+	case isIntKind(t.Kind()) || isUintKind(t.Kind()):
+		return ParamInt, true
+	case isFloatKind(t.Kind()):
+		return ParamFloat, true
+	}
+	return "", false
+}
+
+// funcKinds returns the kinds of field values that convert to t.
+func funcKinds(t reflect.Type) []reflect.Kind {
+	switch {
+	case t.Kind() == reflect.Interface:
+		return nil
+	case isFloatKind(t.Kind()):
+		return append(integerKinds(), reflect.Float32, reflect.Float64)
+	case isIntKind(t.Kind()) || isUintKind(t.Kind()):
+		return integerKinds()
+	}
+	return []reflect.Kind{t.Kind()}
+}
+
+func integerKinds() []reflect.Kind {
+	return []reflect.Kind{
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+	}
+}
+
+// funcChecker returns the checker calling the function registered with
+// RegisterFunc or RegisterParamFunc.
+func funcChecker(fn reflect.Value) CheckFunc {
+	fnType := fn.Type()
+	fixed := fnType.NumIn() - 1
+	if fnType.IsVariadic() {
+		fixed--
+	}
+
+	return func(c CheckerContext) *ErrContext {
+		params := c.Rule.params
+		if len(params) < fixed || !fnType.IsVariadic() && len(params) > fixed {
+			return MakeCheckerParamError(c)
+		}
+
+		args := make([]reflect.Value, 1, 1+len(params))
+		for i, param := range params {
+			arg, ok := funcParam(param, funcParamType(fnType, i+1))
+			if !ok {
+				return MakeCheckerParamError(c)
+			}
+			args = append(args, arg)
+		}
+
+		if c.FieldValue == nil {
+			return nil
+		}
+		if c.FieldValue == "" && fnType.In(0).Kind() == reflect.String {
+			return emptyString(c)
+		}
+		value, ok := funcValue(c.FieldValue, fnType.In(0))
+		if !ok {
+			return MakeValueTypeError(c)
+		}
+		args[0] = value
+
+		if fn.Call(args)[0].Bool() {
+			return nil
+		}
+		ctx := NewErrorContext(c)
+		if len(params) > 0 {
+			ctx.SetFieldLimitValue(strings.Join(params, ", "))
+		}
+		return ctx
+	}
+}
+
+// funcValue converts a field value to t. ok is false if it doesn't
+// convert, including numbers that don't fit t.
+func funcValue(v interface{}, t reflect.Type) (value reflect.Value, ok bool) {
+	value = reflect.ValueOf(v)
+	kind := value.Kind()
+	switch {
+	case value.Type().AssignableTo(t):
+		return value, true
+
+	case isIntKind(t.Kind()) && isIntKind(kind):
+		if reflect.Zero(t).OverflowInt(value.Int()) {
+			return reflect.Value{}, false
+		}
+	case isIntKind(t.Kind()) && isUintKind(kind):
+		if value.Uint() > math.MaxInt64 || reflect.Zero(t).OverflowInt(int64(value.Uint())) {
+			return reflect.Value{}, false
+		}
+	case isUintKind(t.Kind()) && isUintKind(kind):
+		if reflect.Zero(t).OverflowUint(value.Uint()) {
+			return reflect.Value{}, false
+		}
+	case isUintKind(t.Kind()) && isIntKind(kind):
+		if value.Int() < 0 || reflect.Zero(t).OverflowUint(uint64(value.Int())) {
+			return reflect.Value{}, false
+		}
+	case isFloatKind(t.Kind()) && isNumberKind(kind):
+	case kind == t.Kind() && value.Type().ConvertibleTo(t):
+	default:
+		return reflect.Value{}, false
+	}
+	return value.Convert(t), true
+}
+
+// funcParam parses a rule param as t.
+func funcParam(param string, t reflect.Type) (reflect.Value, bool) {
+	if t.Kind() == reflect.String {
+		return reflect.ValueOf(param).Convert(t), true
+	}
+
+	param = strings.TrimSpace(param)
+	var value interface{}
+	var err error
+	switch {
+	case t == durationType:
+		value, err = parseDuration(param)
+	case t == timeType:
+		value, err = parseTimeParam(param)
+	case t.Kind() == reflect.Bool:
+		value, err = strconv.ParseBool(param)
+	case isIntKind(t.Kind()):
+		value, err = strconv.ParseInt(param, 10, t.Bits())
+	case isUintKind(t.Kind()):
+		value, err = strconv.ParseUint(param, 10, t.Bits())
+	case isFloatKind(t.Kind()):
+		value, err = strconv.ParseFloat(param, t.Bits())
+	default:
+		return reflect.Value{}, false
+	}
+	if err != nil {
+		return reflect.Value{}, false
+	}
+	return reflect.ValueOf(value).Convert(t), true
+}
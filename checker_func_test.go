@@ -0,0 +1,245 @@
+package govalid
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// unregister removes a checker registered by a test, with its templates.
+func unregister(name string) {
+	delete(Checkers, name)
+	delete(checkerSpecs, name)
+	for _, templates := range errorTemplateSet {
+		delete(templates, name)
+	}
+	for _, templates := range checkerTemplateSet {
+		delete(templates, name)
+	}
+}
+
+// =============================================================================
+// RegisterFunc
+// =============================================================================
+
+func Test_RegisterFunc(t *testing.T) {
+	defer unregister("even")
+	err := RegisterFunc("even", func(v int64) bool { return v%2 == 0 }, map[language.Tag]string{
+		language.Chinese: "应为偶数",
+		language.English: " should be even",
+	})
+	assert.Nil(t, err)
+
+	type count int8
+	n := 4
+	type form struct {
+		A int    `valid:"even" label:"A"`
+		B uint16 `valid:"even" label:"B"`
+		C count  `valid:"even" label:"C"`
+		D *int   `valid:"even" label:"D"`
+	}
+
+	_, ok := Check(form{A: 2, B: 4, C: 6, D: &n})
+	assert.True(t, ok)
+
+	errs, ok := Check(form{A: 1, B: 3, C: 5})
+	assert.False(t, ok)
+	assert.Equal(t, 3, len(errs))
+	assert.Equal(t, "A应为偶数", errs[0].Error())
+	assert.Equal(t, "C应为偶数", errs[2].Error())
+
+	errs, _ = Check(struct {
+		E float64 `valid:"even" label:"E"`
+		F uint64  `valid:"even" label:"F"`
+	}{E: 2, F: 1 << 63})
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "E参数类型不正确", errs[0].Error())
+	assert.Equal(t, "F参数类型不正确", errs[1].Error())
+
+	errs, _ = Check(form{A: 1}, language.English)
+	assert.Equal(t, "A should be even", errs[0].Error())
+
+	spec, ok := Describe("even")
+	assert.True(t, ok)
+	assert.Equal(t, ParamSchema{}, spec.Params)
+	assert.Contains(t, spec.Kinds, reflect.Uint8)
+	assert.NotContains(t, spec.Kinds, reflect.Float64)
+
+	// Resetting the templates keeps those of registered checkers.
+	SetMessageTemplates(map[string]string{"even": "须为偶数"})
+	ResetMessageTemplates()
+	errs, _ = Check(form{A: 1})
+	assert.Equal(t, "A应为偶数", errs[0].Error())
+}
+
+func Test_RegisterFunc_Strings(t *testing.T) {
+	type email string
+	defer unregister("corpEmail")
+	err := RegisterFunc("corpEmail", func(v email) bool { return strings.HasSuffix(string(v), "@corp.com") }, map[language.Tag]string{
+		language.Chinese: "不是公司邮箱",
+	})
+	assert.Nil(t, err)
+
+	type form struct {
+		Email email  `valid:"corpEmail" label:"邮箱"`
+		Other string `valid:"corpEmail" label:"其他"`
+	}
+
+	_, ok := Check(form{})
+	assert.True(t, ok)
+	_, ok = Check(form{Email: "a@corp.com", Other: "b@corp.com"})
+	assert.True(t, ok)
+
+	errs, ok := Check(form{Email: "a@example.com"})
+	assert.False(t, ok)
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "邮箱不是公司邮箱", errs[0].Error())
+
+	errs, err = CheckContext(context.Background(), form{}, WithStrictEmpty(true))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(errs))
+}
+
+func Test_RegisterFunc_Invalid(t *testing.T) {
+	defer unregister("bad")
+	for _, fn := range []interface{}{
+		nil,
+		"even",
+		func() bool { return true },
+		func(v int) {},
+		func(v int) error { return nil },
+		func(v ...int) bool { return true },
+		func(v, n int) bool { return true },
+	} {
+		assert.NotNil(t, RegisterFunc("bad", fn, nil), "%T", fn)
+	}
+	_, ok := Checkers["bad"]
+	assert.False(t, ok)
+}
+
+// =============================================================================
+// RegisterParamFunc
+// =============================================================================
+
+func Test_RegisterParamFunc(t *testing.T) {
+	defer unregister("range")
+	err := RegisterParamFunc("range", func(v, lo, hi float64) bool { return lo <= v && v <= hi }, map[language.Tag]string{
+		language.Chinese: "应在{limit}之间}}",
+		language.English: " should be between {limit}}}",
+	})
+	assert.Nil(t, err)
+
+	type form struct {
+		Score int     `valid:"range:1,10" label:"分数"`
+		Rate  float32 `valid:"range:0,0.5" label:"比例"`
+	}
+
+	_, ok := Check(form{Score: 10, Rate: 0.5})
+	assert.True(t, ok)
+
+	errs, ok := Check(form{Score: 11, Rate: 0.75})
+	assert.False(t, ok)
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "分数应在1, 10之间", errs[0].Error())
+	assert.Equal(t, "比例应在0, 0.5之间", errs[1].Error())
+
+	errs, _ = Check(form{Score: 0, Rate: 0.1}, language.English)
+	assert.Equal(t, "分数 should be between 1, 10", errs[0].Error())
+
+	errs, _ = Check(struct {
+		A int `valid:"range:1" label:"A"`
+		B int `valid:"range:1,x" label:"B"`
+	}{})
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "A检查规则入参错误", errs[0].Error())
+	assert.Equal(t, "B检查规则入参错误", errs[1].Error())
+
+	spec, _ := Describe("range")
+	assert.Equal(t, ParamSchema{Min: 2, Max: 2, Types: []ParamType{ParamFloat, ParamFloat}}, spec.Params)
+}
+
+func Test_RegisterParamFunc_Variadic(t *testing.T) {
+	defer unregister("prefix")
+	err := RegisterParamFunc("prefix", func(v string, prefixes ...string) bool {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(v, prefix) {
+				return true
+			}
+		}
+		return false
+	}, map[language.Tag]string{language.Chinese: "应以{limit}之一开头}}"})
+	assert.Nil(t, err)
+
+	type form struct {
+		Code string `valid:"prefix:CN,HK" label:"编码"`
+	}
+	_, ok := Check(form{Code: "HK-1"})
+	assert.True(t, ok)
+
+	errs, _ := Check(form{Code: "US-1"})
+	assert.Equal(t, "编码应以CN, HK之一开头", errs[0].Error())
+
+	spec, _ := Describe("prefix")
+	assert.Equal(t, ParamSchema{Variadic: true, Types: []ParamType{ParamString}}, spec.Params)
+}
+
+func Test_RegisterParamFunc_Times(t *testing.T) {
+	fixClock(t, time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC))
+	defer unregister("within")
+	err := RegisterParamFunc("within", func(v time.Time, since time.Time, window time.Duration) bool {
+		return !v.Before(since) && v.Sub(since) <= window
+	}, map[language.Tag]string{language.Chinese: "不在时间窗口内}}"})
+	assert.Nil(t, err)
+
+	type form struct {
+		At time.Time `valid:"within:today,12h" label:"时间"`
+	}
+	_, ok := Check(form{At: time.Date(2024, 5, 10, 11, 0, 0, 0, time.UTC)})
+	assert.True(t, ok)
+
+	errs, _ := Check(form{At: time.Date(2024, 5, 11, 1, 0, 0, 0, time.UTC)})
+	assert.Equal(t, "时间不在时间窗口内", errs[0].Error())
+
+	assert.Nil(t, Compile(reflect.TypeOf(form{})))
+	assert.NotNil(t, Compile(reflect.TypeOf(struct {
+		At time.Time `valid:"within:today,x"`
+		N  int       `valid:"within:today,12h"`
+	}{})))
+}
+
+func Test_RegisterParamFunc_Compile(t *testing.T) {
+	defer unregister("window")
+	err := RegisterParamFunc("window", func(v int, size uint8, offset int8) bool { return true }, nil)
+	assert.Nil(t, err)
+
+	// Params are checked as the argument types, as Check parses them.
+	assert.Nil(t, Compile(reflect.TypeOf(struct {
+		N int `valid:"window:255,-128"`
+	}{})))
+	err = Compile(reflect.TypeOf(struct {
+		A int `valid:"window:-1,0"`
+		B int `valid:"window:1,300"`
+	}{}))
+	if assert.NotNil(t, err) {
+		errs := err.(*CompileError).Errors
+		assert.Equal(t, 2, len(errs))
+		assert.Equal(t, `A: window: param 1 "-1": does not parse as uint8`, errs[0].Error())
+		assert.Equal(t, `B: window: param 2 "300": does not parse as int8`, errs[1].Error())
+	}
+}
+
+func Test_RegisterParamFunc_Invalid(t *testing.T) {
+	defer unregister("bad")
+	for _, fn := range []interface{}{
+		func(v int) bool { return true },
+		func(v int, p []int) bool { return true },
+		func(v int, p string, q ...int) bool { return true },
+	} {
+		assert.NotNil(t, RegisterParamFunc("bad", fn, nil), "%T", fn)
+	}
+}
@@ -0,0 +1,361 @@
+package govalid
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// isDuration reports whether the field value is a time.Duration.
+func isDuration(c CheckerContext) bool {
+	return reflect.TypeOf(c.FieldValue) == durationType
+}
+
+// parseDuration parses a duration bound, either in nanoseconds or in
+// time.ParseDuration syntax such as "30s" or "1h30m".
+func parseDuration(param string) (time.Duration, error) {
+	if n, err := strconv.ParseInt(param, 10, 64); err == nil {
+		return time.Duration(n), nil
+	}
+	return time.ParseDuration(param)
+}
+
+// formatDuration renders a duration without trailing zero units, e.g.
+// "1h30m" rather than "1h30m0s".
+func formatDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// decimalPattern matches a decimal number with an optional exponent, as
+// in json.Number. Exponents are capped at four digits to keep parsing
+// cheap.
+var decimalPattern = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d{1,4})?$`)
+
+// parseDecimal parses a decimal number string exactly.
+func parseDecimal(s string) (*big.Rat, bool) {
+	s = strings.TrimSpace(s)
+	if !decimalPattern.MatchString(s) {
+		return nil, false
+	}
+	return new(big.Rat).SetString(s)
+}
+
+// ratOf returns the exact value of a number: a value of a native number
+// kind, a big.Int, big.Float or big.Rat, or a decimal string such as a
+// json.Number. Floats stand for their shortest decimal representation, so
+// float64(0.1) is exactly 1/10.
+func ratOf(v interface{}) (*big.Rat, bool) {
+	switch v := v.(type) {
+	case nil:
+		return nil, false
+	case big.Int:
+		return new(big.Rat).SetInt(&v), true
+	case big.Float:
+		if v.IsInf() {
+			return nil, false
+		}
+		return parseDecimal(v.Text('g', -1))
+	case big.Rat:
+		return new(big.Rat).Set(&v), true
+	case string:
+		return parseDecimal(v)
+	}
+
+	value := reflect.ValueOf(v)
+	switch {
+	case isIntKind(value.Kind()):
+		return new(big.Rat).SetInt64(value.Int()), true
+	case isUintKind(value.Kind()):
+		return new(big.Rat).SetUint64(value.Uint()), true
+	case isFloatKind(value.Kind()):
+		f := value.Float()
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return nil, false
+		}
+		bits := 64
+		if value.Kind() == reflect.Float32 {
+			bits = 32
+		}
+		return parseDecimal(strconv.FormatFloat(f, 'g', -1, bits))
+	}
+	return nil, false
+}
+
+// isNumber reports whether the field value is a number: a value of a
+// native number kind, or one that ratOf understands.
+func isNumber(c CheckerContext) bool {
+	if c.FieldValue == nil {
+		return false
+	}
+	if isNumberKind(reflect.TypeOf(c.FieldValue).Kind()) {
+		return true
+	}
+	_, ok := ratOf(c.FieldValue)
+	return ok
+}
+
+// notNumber returns the error of a numeric checker for a field value that
+// isn't a number. Empty strings are handled like by the string checkers.
+func notNumber(c CheckerContext) *ErrContext {
+	if c.FieldValue == "" {
+		return emptyString(c)
+	}
+	return MakeValueTypeError(c)
+}
+
+// compareBound parses param as a bound of the numeric field and compares
+// the field value against it. The returned limit is the bound in the form
+// shown in error messages. ok is false if param doesn't parse.
+func compareBound(c CheckerContext, param string) (cmp int, limit interface{}, ok bool) {
+	value := reflect.ValueOf(c.FieldValue)
+	param = strings.TrimSpace(param)
+
+	switch {
+	case isIntKind(value.Kind()) && isDuration(c):
+		bound, err := parseDuration(param)
+		if err != nil {
+			return 0, nil, false
+		}
+		return compareInt64(value.Int(), int64(bound)), formatDuration(bound), true
+
+	case isIntKind(value.Kind()):
+		bound, err := strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			return 0, nil, false
+		}
+		return compareInt64(value.Int(), bound), bound, true
+
+	case isUintKind(value.Kind()):
+		bound, err := strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			return 0, nil, false
+		}
+		return compareUint64(value.Uint(), bound), bound, true
+
+	case isFloatKind(value.Kind()):
+		bound, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return 0, nil, false
+		}
+		return compareFloat64(value.Float(), bound), bound, true
+	}
+
+	// Arbitrary-precision numbers and decimal strings compare exactly.
+	number, ok := ratOf(c.FieldValue)
+	if !ok {
+		return 0, nil, false
+	}
+	bound, ok := parseDecimal(param)
+	if !ok {
+		return 0, nil, false
+	}
+	return number.Cmp(bound), param, true
+}
+
+// between checks that the field lies within the inclusive range given by
+// its two params. Numbers are compared as numbers, and so are strings if
+// both params are decimal numbers. Everything else is compared as a time
+// (see timeBetween).
+func between(c CheckerContext) *ErrContext {
+	if _, ok := c.FieldValue.(string); ok && len(c.Rule.params) == 2 {
+		_, lowerOK := parseDecimal(c.Rule.params[0])
+		_, upperOK := parseDecimal(c.Rule.params[1])
+		if lowerOK && upperOK && !isNumber(c) {
+			return notNumber(c)
+		}
+	}
+	if !isNumber(c) {
+		return timeBetween(c)
+	}
+	if len(c.Rule.params) != 2 {
+		return MakeCheckerParamError(c)
+	}
+
+	lowerCmp, lower, ok := compareBound(c, c.Rule.params[0])
+	if !ok {
+		return MakeCheckerParamError(c)
+	}
+	upperCmp, upper, ok := compareBound(c, c.Rule.params[1])
+	if !ok {
+		return MakeCheckerParamError(c)
+	}
+
+	if lowerCmp >= 0 && upperCmp <= 0 {
+		return nil
+	}
+	ctx := NewErrorContext(c)
+	ctx.SetFieldLimitValue(fmt.Sprintf("[%v, %v]", lower, upper))
+	return ctx
+}
+
+func gt(c CheckerContext) *ErrContext {
+	return exclusiveBound(c, func(cmp int) bool { return cmp > 0 })
+}
+
+func lt(c CheckerContext) *ErrContext {
+	return exclusiveBound(c, func(cmp int) bool { return cmp < 0 })
+}
+
+// exclusiveBound checks the field against its single param with accept,
+// which receives the result of comparing the field to the bound.
+func exclusiveBound(c CheckerContext, accept func(cmp int) bool) *ErrContext {
+	if len(c.Rule.params) != 1 {
+		return MakeCheckerParamError(c)
+	}
+	if !isNumber(c) {
+		return notNumber(c)
+	}
+
+	cmp, limit, ok := compareBound(c, c.Rule.params[0])
+	if !ok {
+		return MakeCheckerParamError(c)
+	}
+
+	if accept(cmp) {
+		return nil
+	}
+	ctx := NewErrorContext(c)
+	ctx.SetFieldLimitValue(limit)
+	return ctx
+}
+
+// multipleOf checks that the field is an integer multiple of its param,
+// e.g. `valid:"multipleof:5"` or `valid:"multipleof:15m"` on a
+// time.Duration.
+func multipleOf(c CheckerContext) *ErrContext {
+	if len(c.Rule.params) != 1 {
+		return MakeCheckerParamError(c)
+	}
+	if !isNumber(c) {
+		return notNumber(c)
+	}
+
+	param := strings.TrimSpace(c.Rule.params[0])
+	value := reflect.ValueOf(c.FieldValue)
+
+	var multiple bool
+	var limit interface{}
+	switch {
+	case isIntKind(value.Kind()) && isDuration(c):
+		d, err := parseDuration(param)
+		if err != nil || d == 0 {
+			return MakeCheckerParamError(c)
+		}
+		multiple, limit = value.Int()%int64(d) == 0, formatDuration(d)
+
+	case isIntKind(value.Kind()):
+		n, err := strconv.ParseInt(param, 10, 64)
+		if err != nil || n == 0 {
+			return MakeCheckerParamError(c)
+		}
+		multiple, limit = value.Int()%n == 0, n
+
+	case isUintKind(value.Kind()):
+		n, err := strconv.ParseUint(param, 10, 64)
+		if err != nil || n == 0 {
+			return MakeCheckerParamError(c)
+		}
+		multiple, limit = value.Uint()%n == 0, n
+
+	case isFloatKind(value.Kind()):
+		n, err := strconv.ParseFloat(param, 64)
+		if err != nil || n == 0 || math.IsInf(n, 0) || math.IsNaN(n) {
+			return MakeCheckerParamError(c)
+		}
+		// Allow for rounding, so that 0.3 is a multiple of 0.1.
+		quotient := value.Float() / n
+		multiple, limit = math.Abs(quotient-math.Round(quotient)) < 1e-9, n
+
+	default:
+		n, ok := parseDecimal(param)
+		if !ok || n.Sign() == 0 {
+			return MakeCheckerParamError(c)
+		}
+		number, _ := ratOf(c.FieldValue)
+		multiple, limit = new(big.Rat).Quo(number, n).IsInt(), param
+	}
+
+	if multiple {
+		return nil
+	}
+	ctx := NewErrorContext(c)
+	ctx.SetFieldLimitValue(limit)
+	return ctx
+}
+
+// sign returns a checker that accepts a numeric field if accept returns
+// true for the result of comparing the field to zero.
+func sign(accept func(cmp int) bool) CheckFunc {
+	return func(c CheckerContext) *ErrContext {
+		if !isNumber(c) {
+			return notNumber(c)
+		}
+
+		cmp, _, _ := compareBound(c, "0")
+		if accept(cmp) {
+			return nil
+		}
+		return NewErrorContext(c)
+	}
+}
+
+// decimal checks that a number fits a SQL-style DECIMAL(precision, scale):
+// at most scale fractional digits and at most precision-scale integer
+// digits, e.g. `valid:"decimal:12,2"`.
+func decimal(c CheckerContext) *ErrContext {
+	if len(c.Rule.params) != 2 {
+		return MakeCheckerParamError(c)
+	}
+	precision, err := strconv.Atoi(strings.TrimSpace(c.Rule.params[0]))
+	if err != nil || precision < 1 {
+		return MakeCheckerParamError(c)
+	}
+	scale, err := strconv.Atoi(strings.TrimSpace(c.Rule.params[1]))
+	if err != nil || scale < 0 || scale > precision {
+		return MakeCheckerParamError(c)
+	}
+
+	number, ok := ratOf(c.FieldValue)
+	if !ok {
+		return notNumber(c)
+	}
+
+	// Scale the number up until it is an integer, giving up past scale.
+	scaled := new(big.Rat).Abs(number)
+	ten := big.NewRat(10, 1)
+	for places := 0; !scaled.IsInt(); places++ {
+		if places == scale {
+			ctx := NewErrorContext(c)
+			ctx.SetTemplate("decimalScale")
+			ctx.SetFieldLimitValue(scale)
+			return ctx
+		}
+		scaled.Mul(scaled, ten)
+	}
+
+	integer := new(big.Int).Quo(number.Num(), number.Denom())
+	digits := 0
+	if integer.Sign() != 0 {
+		digits = len(integer.Abs(integer).String())
+	}
+	if digits > precision-scale {
+		ctx := NewErrorContext(c)
+		ctx.SetFieldLimitValue(precision - scale)
+		return ctx
+	}
+	return nil
+}
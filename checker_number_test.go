@@ -0,0 +1,264 @@
+package govalid
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func Test_Between_Numbers(t *testing.T) {
+	type form struct {
+		Count int     `valid:"between:1,10" label:"数量"`
+		Ratio float64 `valid:"between:0,1" label:"比例"`
+		Level uint8   `valid:"between:1,5" label:"等级"`
+	}
+
+	_, ok := Check(form{Count: 10, Ratio: 0, Level: 1})
+	assert.True(t, ok)
+
+	errs, ok := Check(form{Count: 11, Ratio: 1.5, Level: 0})
+	assert.False(t, ok)
+	assert.Equal(t, 3, len(errs))
+	assert.Equal(t, "数量应在[1, 10]范围内", errs[0].Error())
+	assert.Equal(t, "比例应在[0, 1]范围内", errs[1].Error())
+	assert.Equal(t, "等级应在[1, 5]范围内", errs[2].Error())
+
+	errs, _ = Check(struct {
+		N int `valid:"between:1"`
+	}{})
+	assert.Equal(t, "N检查规则入参错误", errs[0].Error())
+}
+
+func Test_ExclusiveBounds(t *testing.T) {
+	type form struct {
+		Price float64 `valid:"gt:0" label:"价格"`
+		Stock int     `valid:"lt:100" label:"库存"`
+	}
+
+	_, ok := Check(form{Price: 0.01, Stock: 99})
+	assert.True(t, ok)
+
+	errs, ok := Check(form{Price: 0, Stock: 100}, language.English)
+	assert.False(t, ok)
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "价格 should be greater than 0", errs[0].Error())
+	assert.Equal(t, "库存 should be less than 100", errs[1].Error())
+
+	errs, _ = Check(struct {
+		S string `valid:"gt:1" label:"S"`
+		B bool   `valid:"lt:1" label:"B"`
+	}{S: "abc"})
+	assert.Equal(t, 2, len(errs))
+}
+
+func Test_MultipleOf(t *testing.T) {
+	type form struct {
+		Qty   int     `valid:"multipleof:5" label:"数量"`
+		Step  float64 `valid:"multipleof:0.1" label:"步长"`
+		Units uint    `valid:"multipleof:2" label:"单位"`
+	}
+
+	_, ok := Check(form{Qty: -15, Step: 0.3, Units: 4})
+	assert.True(t, ok)
+
+	errs, ok := Check(form{Qty: 7, Step: 0.25, Units: 3})
+	assert.False(t, ok)
+	assert.Equal(t, 3, len(errs))
+	assert.Equal(t, "数量应为5的倍数", errs[0].Error())
+	assert.Equal(t, "步长应为0.1的倍数", errs[1].Error())
+
+	errs, _ = Check(struct {
+		N int `valid:"multipleof:0"`
+	}{N: 3})
+	assert.Equal(t, "N检查规则入参错误", errs[0].Error())
+}
+
+func Test_SignCheckers(t *testing.T) {
+	type form struct {
+		Amount  float32 `valid:"positive" label:"金额"`
+		Offset  int     `valid:"negative" label:"偏移"`
+		Divisor int64   `valid:"nonzero" label:"除数"`
+	}
+
+	_, ok := Check(form{Amount: 0.5, Offset: -1, Divisor: -3})
+	assert.True(t, ok)
+
+	errs, ok := Check(form{})
+	assert.False(t, ok)
+	assert.Equal(t, 3, len(errs))
+	assert.Equal(t, "金额应为正数", errs[0].Error())
+	assert.Equal(t, "偏移应为负数", errs[1].Error())
+	assert.Equal(t, "除数不能为零", errs[2].Error())
+
+	// nil pointers are absent and pass.
+	_, ok = Check(struct {
+		N *int `valid:"positive"`
+	}{})
+	assert.True(t, ok)
+}
+
+func Test_DurationBounds(t *testing.T) {
+	type config struct {
+		Timeout  time.Duration  `valid:"min:1s;max:30s" label:"超时"`
+		Interval *time.Duration `valid:"between:1m,1h30m;multipleof:15m" label:"间隔"`
+		Retry    time.Duration  `valid:"gt:0;lt:1000000000" label:"重试"`
+	}
+
+	interval := 45 * time.Minute
+	_, ok := Check(config{Timeout: 10 * time.Second, Interval: &interval, Retry: time.Millisecond})
+	assert.True(t, ok)
+
+	interval = 2 * time.Hour
+	errs, ok := Check(config{Timeout: time.Minute, Interval: &interval, Retry: time.Second})
+	assert.False(t, ok)
+	assert.Equal(t, 3, len(errs))
+	assert.Equal(t, "超时应小于30s", errs[0].Error())
+	assert.Equal(t, "间隔应在[1m, 1h30m]范围内", errs[1].Error())
+	assert.Equal(t, "重试应小于1s", errs[2].Error())
+
+	errs, _ = Check(config{Timeout: time.Second, Retry: time.Millisecond, Interval: func() *time.Duration {
+		d := 20 * time.Minute
+		return &d
+	}()})
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "间隔应为15m的倍数", errs[0].Error())
+
+	errs, _ = Check(struct {
+		D time.Duration `valid:"max:soon"`
+	}{})
+	assert.Equal(t, "D检查规则入参错误", errs[0].Error())
+}
+
+func Test_formatDuration(t *testing.T) {
+	assert.Equal(t, "30s", formatDuration(30*time.Second))
+	assert.Equal(t, "1m", formatDuration(time.Minute))
+	assert.Equal(t, "1h", formatDuration(time.Hour))
+	assert.Equal(t, "1h30m", formatDuration(90*time.Minute))
+	assert.Equal(t, "1m30s", formatDuration(90*time.Second))
+	assert.Equal(t, "1.5s", formatDuration(1500*time.Millisecond))
+}
+
+// =============================================================================
+// Arbitrary-precision numbers: big.*, json.Number and decimal strings
+// =============================================================================
+
+func Test_BigNumbers(t *testing.T) {
+	type payment struct {
+		Amount   json.Number `valid:"min:0.01;max:99999999.99" label:"金额"`
+		Balance  *big.Int    `valid:"between:0,100000000000000000000" label:"余额"`
+		Rate     *big.Rat    `valid:"gt:0;lt:1" label:"费率"`
+		Fee      big.Float   `valid:"max:10.5" label:"手续费"`
+		Discount string      `valid:"min:0;multipleof:0.05" label:"折扣"`
+	}
+
+	balance, _ := new(big.Int).SetString("100000000000000000000", 10)
+	ok := func(p payment) bool {
+		_, ok := Check(p)
+		return ok
+	}
+
+	assert.True(t, ok(payment{
+		Amount:   "0.01",
+		Balance:  balance,
+		Rate:     big.NewRat(1, 3),
+		Fee:      *big.NewFloat(10.5),
+		Discount: "0.15",
+	}))
+
+	// Absent values pass; an empty string is left to required.
+	assert.True(t, ok(payment{}))
+
+	tooMuch := new(big.Int).Add(balance, big.NewInt(1))
+	errs, valid := Check(payment{
+		Amount:   "0.009",
+		Balance:  tooMuch,
+		Rate:     big.NewRat(1, 1),
+		Fee:      *big.NewFloat(10.51),
+		Discount: "0.17",
+	})
+	assert.False(t, valid)
+	assert.Equal(t, 5, len(errs))
+	assert.Equal(t, "金额应大于0.01", errs[0].Error())
+	assert.Equal(t, "余额应在[0, 100000000000000000000]范围内", errs[1].Error())
+	assert.Equal(t, "费率应小于1", errs[2].Error())
+	assert.Equal(t, "手续费应小于10.5", errs[3].Error())
+	assert.Equal(t, "折扣应为0.05的倍数", errs[4].Error())
+
+	// min and max ignore strings that aren't numbers, as they always
+	// have; the other numeric checkers report them.
+	errs, _ = Check(payment{Amount: "ten", Discount: "1/2"})
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "折扣参数类型不正确", errs[0].Error())
+}
+
+func Test_NumericStrings_MinMax(t *testing.T) {
+	type form struct {
+		Code string `valid:"min:1;max:5" label:"编码"`
+	}
+
+	// Strings that aren't numbers pass, as they did before decimal
+	// strings were compared.
+	_, ok := Check(form{Code: "abc"})
+	assert.True(t, ok)
+
+	errs, _ := Check(form{Code: "9"})
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "编码应小于5", errs[0].Error())
+}
+
+func Test_NumericStrings_Between(t *testing.T) {
+	type form struct {
+		Score string `valid:"between:0,100" label:"分数"`
+		Date  string `valid:"between:2024-01-01,2024-12-31" label:"日期"`
+	}
+
+	_, ok := Check(form{Score: "99.5", Date: "2024-03-01"})
+	assert.True(t, ok)
+
+	errs, _ := Check(form{Score: "1e3", Date: "2023-03-01"})
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "分数应在[0, 100]范围内", errs[0].Error())
+	assert.Equal(t, "日期应在[2024-01-01, 2024-12-31]范围内", errs[1].Error())
+
+	errs, _ = Check(form{Score: "abc"})
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "分数参数类型不正确", errs[0].Error())
+}
+
+func Test_Decimal(t *testing.T) {
+	type form struct {
+		Price  string      `valid:"decimal:12,2" label:"价格"`
+		Weight float64     `valid:"decimal:5,3" label:"重量"`
+		Total  json.Number `valid:"decimal:4,0" label:"总数"`
+		Ratio  *big.Rat    `valid:"decimal:3,3" label:"比率"`
+	}
+
+	_, ok := Check(form{Price: "9999999999.99", Weight: 12.125, Total: "-9999", Ratio: big.NewRat(1, 8)})
+	assert.True(t, ok)
+
+	_, ok = Check(form{Price: "-0.5", Weight: 0.1, Total: "12e2"})
+	assert.True(t, ok)
+
+	errs, ok := Check(form{Price: "10000000000", Weight: 0.0001, Total: "1.5", Ratio: big.NewRat(1, 3)}, language.English)
+	assert.False(t, ok)
+	assert.Equal(t, 4, len(errs))
+	assert.Equal(t, "价格 can have at most 10 integer digits", errs[0].Error())
+	assert.Equal(t, "重量 can have at most 3 decimal places", errs[1].Error())
+	assert.Equal(t, "总数 can have at most 0 decimal places", errs[2].Error())
+	assert.Equal(t, "比率 can have at most 3 decimal places", errs[3].Error())
+
+	for _, rule := range []string{"decimal", "decimal:2", "decimal:0,0", "decimal:2,3", "decimal:a,b"} {
+		rules, err := parseRules(rule)
+		assert.Nil(t, err)
+		errs := Checkers["decimal"](CheckerContext{
+			FieldLabel: "N",
+			FieldValue: "1",
+			Rule:       rules[0],
+		})
+		assert.Equal(t, "N检查规则入参错误", errs.Error(), rule)
+	}
+}
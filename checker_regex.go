@@ -0,0 +1,88 @@
+package govalid
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sync"
+)
+
+// regexCache holds the compiled expressions of regex rules, keyed by the
+// expression.
+var regexCache sync.Map
+
+// compileRegex compiles expr, or returns its cached compilation.
+func compileRegex(expr string) (*regexp.Regexp, error) {
+	if re, ok := regexCache.Load(expr); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	regexCache.Store(expr, re)
+	return re, nil
+}
+
+// patterns is the registry of named patterns for the pattern checker.
+var patterns = map[string]*regexp.Regexp{}
+
+// RegisterPattern compiles expr and registers it under name, so that tags
+// can refer to it as `valid:"pattern:<name>"`. Registering a name again
+// replaces its pattern.
+//
+// Example:
+//
+//	govalid.RegisterPattern("sku", `^[A-Z]{3}-\d+$`)
+func RegisterPattern(name, expr string) error {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return fmt.Errorf("compile pattern %q: %v", name, err)
+	}
+	patterns[name] = re
+	return nil
+}
+
+// regex checks that a string field matches the expression given by its
+// param. Unquoted, the expression runs to the end of the tag, e.g.
+// `valid:"required;regex:^[a-z]+(,[a-z]+)*$"`; quoted, other rules can
+// follow it, e.g. `valid:"regex:'^\\d+;\\d+$';maxlen:20"`. Backslashes are
+// doubled since struct tag values are Go string literals.
+func regex(c CheckerContext) *ErrContext {
+	if len(c.Rule.params) != 1 || c.Rule.params[0] == "" {
+		return MakeCheckerParamError(c)
+	}
+	re, err := compileRegex(c.Rule.params[0])
+	if err != nil {
+		return MakeCheckerParamError(c)
+	}
+	return matchPattern(c, re)
+}
+
+// pattern checks that a string field matches the pattern registered under
+// the name given by its param, e.g. `valid:"pattern:sku"`.
+func pattern(c CheckerContext) *ErrContext {
+	if len(c.Rule.params) != 1 {
+		return MakeCheckerParamError(c)
+	}
+	re, ok := patterns[c.Rule.params[0]]
+	if !ok {
+		return MakeCheckerParamError(c)
+	}
+	return matchPattern(c, re)
+}
+
+func matchPattern(c CheckerContext, re *regexp.Regexp) *ErrContext {
+	if c.FieldValue == nil || reflect.TypeOf(c.FieldValue).Kind() != reflect.String {
+		return MakeValueTypeError(c)
+	}
+
+	value := c.FieldValue.(string)
+	if value == "" {
+		return emptyString(c)
+	}
+	if !re.MatchString(value) {
+		return NewErrorContext(c)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package govalid
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func Test_Regex(t *testing.T) {
+	type form struct {
+		Tags  string `valid:"regex:^[a-z]+(,[a-z]+)*$" label:"标签"`
+		Range string `valid:"regex:'^\\d+;\\d+$';maxlen:7" label:"范围"`
+	}
+
+	_, ok := Check(form{Tags: "go,rust", Range: "10;20"})
+	assert.True(t, ok)
+
+	_, ok = Check(form{})
+	assert.True(t, ok)
+
+	errs, ok := Check(form{Tags: "go,", Range: "100;2000"})
+	assert.False(t, ok)
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "标签格式不正确", errs[0].Error())
+	assert.Equal(t, "范围长度应小于7", errs[1].Error())
+
+	errs, _ = Check(form{Range: "1-2"}, language.English)
+	assert.Equal(t, "范围 is not in the correct format", errs[0].Error())
+
+	errs, _ = Check(struct {
+		S string `valid:"regex:([a-z" label:"S"`
+		E string `valid:"regex:" label:"E"`
+	}{S: "a", E: "a"})
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "S检查规则入参错误", errs[0].Error())
+	assert.Equal(t, "E检查规则在第7个字符处格式错误", errs[1].Error())
+}
+
+func Test_Regex_Cached(t *testing.T) {
+	expr := `^cached-\d+$`
+	first, err := compileRegex(expr)
+	assert.Nil(t, err)
+	second, err := compileRegex(expr)
+	assert.Nil(t, err)
+	assert.Same(t, first, second)
+}
+
+func Test_Pattern(t *testing.T) {
+	assert.Nil(t, RegisterPattern("sku", `^[A-Z]{3}-\d+$`))
+	defer delete(patterns, "sku")
+	assert.NotNil(t, RegisterPattern("broken", `([A-Z]`))
+
+	type product struct {
+		SKU     string  `valid:"required;pattern:sku" label:"货号"`
+		Legacy  *string `valid:"pattern:sku" label:"旧货号"`
+		Unknown string  `valid:"pattern:nope" label:"未知"`
+	}
+
+	legacy := "ABC-1"
+	errs, _ := Check(product{SKU: "ABC-123", Legacy: &legacy})
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "未知检查规则入参错误", errs[0].Error())
+
+	errs, _ = Check(product{SKU: "abc-123"})
+	assert.Equal(t, "货号格式不正确", errs[0].Error())
+}
+
+func Test_BuiltinPatternsOverridable(t *testing.T) {
+	old := TelPattern
+	defer func() { TelPattern = old }()
+
+	v := struct {
+		Tel string `valid:"tel" label:"电话"`
+	}{Tel: "+44 20 7946 0000"}
+
+	_, ok := Check(v)
+	assert.False(t, ok)
+
+	TelPattern = regexp.MustCompile(`^\+?[\d ]+$`)
+	_, ok = Check(v)
+	assert.True(t, ok)
+}
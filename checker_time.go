@@ -0,0 +1,292 @@
+package govalid
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Clock returns the current time. Relative time expressions such as "now"
+// or "today-18y" and the age checker are evaluated against it, so tests can
+// replace it with a fixed clock.
+var Clock = time.Now
+
+// TimeLayouts are the layouts tried, in order, when a string field or an
+// absolute time param is interpreted as a time.
+var TimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// relativeTimePattern matches a single offset of a relative time
+// expression, e.g. "+24h", "-18y" or "+1mo".
+var relativeTimePattern = regexp.MustCompile(`^([+-])(\d+)(y|mo|w|d|h|ms|us|µs|ns|m|s)`)
+
+// parseTimeParam parses an absolute time in one of the TimeLayouts, or a
+// relative expression starting with "now" or "today" (midnight) followed
+// by any number of offsets, e.g. "now+24h" or "today-18y".
+func parseTimeParam(param string) (time.Time, error) {
+	param = strings.TrimSpace(param)
+
+	var t time.Time
+	switch {
+	case strings.HasPrefix(param, "now"):
+		t = Clock()
+		param = strings.TrimPrefix(param, "now")
+	case strings.HasPrefix(param, "today"):
+		now := Clock()
+		t = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		param = strings.TrimPrefix(param, "today")
+	default:
+		return parseTime(param)
+	}
+
+	for param != "" {
+		match := relativeTimePattern.FindStringSubmatch(param)
+		if match == nil {
+			return time.Time{}, fmt.Errorf("invalid time offset %q", param)
+		}
+		param = param[len(match[0]):]
+
+		n, err := strconv.Atoi(match[2])
+		if err != nil {
+			return time.Time{}, err
+		}
+		if match[1] == "-" {
+			n = -n
+		}
+
+		switch match[3] {
+		case "y":
+			t = t.AddDate(n, 0, 0)
+		case "mo":
+			t = t.AddDate(0, n, 0)
+		case "w":
+			t = t.AddDate(0, 0, 7*n)
+		case "d":
+			t = t.AddDate(0, 0, n)
+		default:
+			d, err := time.ParseDuration(strconv.Itoa(n) + match[3])
+			if err != nil {
+				return time.Time{}, err
+			}
+			t = t.Add(d)
+		}
+	}
+	return t, nil
+}
+
+// parseTime parses s in the first matching layout of TimeLayouts. Times
+// without a zone are in the location of Clock, like "now" and "today", so
+// that dates compare the same way near midnight.
+func parseTime(s string) (time.Time, error) {
+	location := Clock().Location()
+	var err error
+	for _, layout := range TimeLayouts {
+		var t time.Time
+		if t, err = time.ParseInLocation(layout, s, location); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// formatTime renders a time limit for error messages, leaving out the
+// clock for midnight.
+func formatTime(t time.Time) string {
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
+// timeValue returns the time of a time.Time field, or of a string field
+// in one of the TimeLayouts. done is true when the checker must return
+// errCtx right away, e.g. for an unparsable string. A zero time is empty,
+// like the empty string.
+func timeValue(c CheckerContext) (t time.Time, done bool, errCtx *ErrContext) {
+	switch v := c.FieldValue.(type) {
+	case time.Time:
+		if v.IsZero() {
+			return time.Time{}, true, emptyString(c)
+		}
+		return v, false, nil
+	case string:
+		if v == "" {
+			return time.Time{}, true, emptyString(c)
+		}
+		t, err := parseTime(v)
+		if err != nil {
+			ctx := NewErrorContext(c)
+			ctx.SetTemplate("datetime")
+			ctx.SetFieldLimitValue(time.RFC3339)
+			return time.Time{}, true, ctx
+		}
+		return t, false, nil
+	}
+	return time.Time{}, true, MakeValueTypeError(c)
+}
+
+func before(c CheckerContext) *ErrContext {
+	return beforeOrAfter(c, "before")
+}
+
+func after(c CheckerContext) *ErrContext {
+	return beforeOrAfter(c, "after")
+}
+
+func beforeOrAfter(c CheckerContext, flag string) *ErrContext {
+	if len(c.Rule.params) != 1 {
+		return MakeCheckerParamError(c)
+	}
+	limit, err := parseTimeParam(c.Rule.params[0])
+	if err != nil {
+		return MakeCheckerParamError(c)
+	}
+
+	value, done, errCtx := timeValue(c)
+	if done {
+		return errCtx
+	}
+
+	if (flag == "before" && value.Before(limit)) || (flag == "after" && value.After(limit)) {
+		return nil
+	}
+	ctx := NewErrorContext(c)
+	ctx.SetFieldLimitValue(formatTime(limit))
+	return ctx
+}
+
+func timeBetween(c CheckerContext) *ErrContext {
+	if len(c.Rule.params) != 2 {
+		return MakeCheckerParamError(c)
+	}
+	lower, err := parseTimeParam(c.Rule.params[0])
+	if err != nil {
+		return MakeCheckerParamError(c)
+	}
+	upper, err := parseTimeParam(c.Rule.params[1])
+	if err != nil {
+		return MakeCheckerParamError(c)
+	}
+
+	value, done, errCtx := timeValue(c)
+	if done {
+		return errCtx
+	}
+
+	if !value.Before(lower) && !value.After(upper) {
+		return nil
+	}
+	ctx := NewErrorContext(c)
+	ctx.SetFieldLimitValue(fmt.Sprintf("[%s, %s]", formatTime(lower), formatTime(upper)))
+	return ctx
+}
+
+// datetime checks that a string field is a time in the layout given by the
+// param, e.g. `valid:"datetime:2006-01-02"`. Layouts with commas must be
+// quoted, e.g. `valid:"datetime:'Jan 2, 2006'"`.
+func datetime(c CheckerContext) *ErrContext {
+	if len(c.Rule.params) != 1 || c.Rule.params[0] == "" {
+		return MakeCheckerParamError(c)
+	}
+	layout := c.Rule.params[0]
+
+	if c.FieldValue == nil || reflect.TypeOf(c.FieldValue).Kind() != reflect.String {
+		return MakeValueTypeError(c)
+	}
+	value := c.FieldValue.(string)
+	if value == "" {
+		return emptyString(c)
+	}
+
+	if _, err := time.Parse(layout, value); err != nil {
+		ctx := NewErrorContext(c)
+		ctx.SetFieldLimitValue(layout)
+		return ctx
+	}
+	return nil
+}
+
+var weekdays = map[string]time.Weekday{}
+
+func init() {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		weekdays[name] = d
+		weekdays[name[:3]] = d
+		weekdays[strconv.Itoa(int(d))] = d
+	}
+}
+
+// weekday checks that the field falls on one of the weekdays given by the
+// params, as English names ("monday"), abbreviations ("mon") or numbers
+// from 0 (Sunday) to 6, e.g. `valid:"weekday:mon,tue,wed,thu,fri"`.
+func weekday(c CheckerContext) *ErrContext {
+	if len(c.Rule.params) == 0 {
+		return MakeCheckerParamError(c)
+	}
+	allowed := make(map[time.Weekday]bool, len(c.Rule.params))
+	for _, p := range c.Rule.params {
+		d, ok := weekdays[strings.ToLower(strings.TrimSpace(p))]
+		if !ok {
+			return MakeCheckerParamError(c)
+		}
+		allowed[d] = true
+	}
+
+	value, done, errCtx := timeValue(c)
+	if done {
+		return errCtx
+	}
+
+	if allowed[value.Weekday()] {
+		return nil
+	}
+	return NewErrorContext(c)
+}
+
+// age checks that the years elapsed since the birthday in the field, as of
+// Clock, lie within the inclusive range of its params, e.g.
+// `valid:"age:18,120"`. The upper bound is optional.
+func age(c CheckerContext) *ErrContext {
+	if len(c.Rule.params) == 0 || len(c.Rule.params) > 2 {
+		return MakeCheckerParamError(c)
+	}
+	minAge, err := strconv.Atoi(c.Rule.params[0])
+	if err != nil {
+		return MakeCheckerParamError(c)
+	}
+	maxAge := -1
+	if len(c.Rule.params) == 2 {
+		if maxAge, err = strconv.Atoi(c.Rule.params[1]); err != nil || maxAge < minAge {
+			return MakeCheckerParamError(c)
+		}
+	}
+
+	birthday, done, errCtx := timeValue(c)
+	if done {
+		return errCtx
+	}
+
+	now := Clock().In(birthday.Location())
+	years := now.Year() - birthday.Year()
+	if now.Month() < birthday.Month() || (now.Month() == birthday.Month() && now.Day() < birthday.Day()) {
+		years--
+	}
+
+	if years >= minAge && (maxAge < 0 || years <= maxAge) {
+		return nil
+	}
+	ctx := NewErrorContext(c)
+	if maxAge < 0 {
+		ctx.SetFieldLimitValue(fmt.Sprintf("[%d, +∞)", minAge))
+	} else {
+		ctx.SetFieldLimitValue(fmt.Sprintf("[%d, %d]", minAge, maxAge))
+	}
+	return ctx
+}
@@ -0,0 +1,186 @@
+package govalid
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// fixClock pins Clock to t for the duration of a test.
+func fixClock(t *testing.T, now time.Time) {
+	t.Helper()
+	old := Clock
+	Clock = func() time.Time { return now }
+	t.Cleanup(func() { Clock = old })
+}
+
+func Test_parseTimeParam(t *testing.T) {
+	fixClock(t, time.Date(2024, 5, 15, 13, 30, 0, 0, time.UTC))
+
+	for _, tc := range []struct {
+		param string
+		want  time.Time
+	}{
+		{"now", time.Date(2024, 5, 15, 13, 30, 0, 0, time.UTC)},
+		{"today", time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC)},
+		{"now+24h", time.Date(2024, 5, 16, 13, 30, 0, 0, time.UTC)},
+		{"now-90m", time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)},
+		{"today-18y", time.Date(2006, 5, 15, 0, 0, 0, 0, time.UTC)},
+		{"today+1mo+2w-1d", time.Date(2024, 6, 28, 0, 0, 0, 0, time.UTC)},
+		{"2020-01-02", time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"2020-01-02T03:04:05Z", time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)},
+	} {
+		got, err := parseTimeParam(tc.param)
+		assert.Nil(t, err, tc.param)
+		assert.True(t, tc.want.Equal(got), "%s: got %v", tc.param, got)
+	}
+
+	for _, param := range []string{"", "tomorrow", "now+", "now+1x", "today-y", "2020-13-01"} {
+		_, err := parseTimeParam(param)
+		assert.NotNil(t, err, param)
+	}
+}
+
+func Test_BeforeAfterBetween(t *testing.T) {
+	fixClock(t, time.Date(2024, 5, 15, 13, 30, 0, 0, time.UTC))
+
+	type booking struct {
+		StartAt time.Time  `valid:"after:now" label:"开始时间"`
+		EndAt   *time.Time `valid:"before:now+30d" label:"结束时间"`
+		Date    string     `valid:"between:2024-01-01,2024-12-31" label:"日期"`
+	}
+
+	start := time.Date(2024, 5, 20, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 5, 21, 9, 0, 0, 0, time.UTC)
+	_, ok := Check(booking{StartAt: start, EndAt: &end, Date: "2024-06-01"})
+	assert.True(t, ok)
+
+	// A nil pointer and an empty string are absent.
+	_, ok = Check(booking{StartAt: start})
+	assert.True(t, ok)
+
+	late := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	errs, ok := Check(booking{
+		StartAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		EndAt:   &late,
+		Date:    "2025-01-01",
+	})
+	assert.False(t, ok)
+	assert.Equal(t, 3, len(errs))
+	assert.Equal(t, "开始时间应晚于2024-05-15 13:30:00", errs[0].Error())
+	assert.Equal(t, "结束时间应早于2024-06-14 13:30:00", errs[1].Error())
+	assert.Equal(t, "日期应在[2024-01-01, 2024-12-31]范围内", errs[2].Error())
+
+	errs, _ = Check(booking{StartAt: start, Date: "not a date"}, language.English)
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "日期 should be a date/time in the format 2006-01-02T15:04:05Z07:00", errs[0].Error())
+}
+
+func Test_BeforeAfterBetween_ZeroTime(t *testing.T) {
+	fixClock(t, time.Date(2024, 5, 15, 13, 30, 0, 0, time.UTC))
+
+	type form struct {
+		T time.Time `valid:"after:2020-01-01" label:"T"`
+		S string    `valid:"after:2020-01-01" label:"S"`
+	}
+
+	// A zero time is absent, like an empty string.
+	_, ok := Check(form{})
+	assert.True(t, ok)
+
+	errs, _ := CheckContext(context.Background(), form{}, WithStrictEmpty(true))
+	assert.Equal(t, 2, len(errs))
+}
+
+func Test_BeforeAfterBetween_Location(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*60*60)
+	fixClock(t, time.Date(2024, 5, 15, 0, 30, 0, 0, shanghai))
+
+	type form struct {
+		Date     string `valid:"before:today" label:"日期"`
+		DateTime string `valid:"between:2024-05-15,now" label:"时间"`
+	}
+
+	// Strings without a zone are read in the location of the clock.
+	_, ok := Check(form{Date: "2024-05-14 23:00:00", DateTime: "2024-05-15 00:10:00"})
+	assert.True(t, ok)
+
+	errs, _ := Check(form{Date: "2024-05-15", DateTime: "2024-05-14"})
+	assert.Equal(t, 2, len(errs))
+}
+
+func Test_Datetime(t *testing.T) {
+	type form struct {
+		Day   string `valid:"datetime:2006-01-02" label:"日期"`
+		Clock string `valid:"datetime:15:04" label:"时刻"`
+		Long  string `valid:"datetime:'Jan 2, 2006'" label:"长日期"`
+	}
+
+	_, ok := Check(form{Day: "2024-02-29", Clock: "23:59", Long: "Feb 3, 2024"})
+	assert.True(t, ok)
+
+	_, ok = Check(form{})
+	assert.True(t, ok)
+
+	errs, ok := Check(form{Day: "2023-02-29", Clock: "24:00", Long: "2024-02-03"}, language.English)
+	assert.False(t, ok)
+	assert.Equal(t, 3, len(errs))
+	assert.Equal(t, "日期 should be a date/time in the format 2006-01-02", errs[0].Error())
+	assert.Equal(t, "时刻 should be a date/time in the format 15:04", errs[1].Error())
+	assert.Equal(t, "长日期 should be a date/time in the format Jan 2, 2006", errs[2].Error())
+}
+
+func Test_Weekday(t *testing.T) {
+	type shift struct {
+		Day  time.Time `valid:"weekday:mon,Tuesday,3,thu,fri" label:"排班日"`
+		Rest string    `valid:"weekday:sat,sun" label:"休息日"`
+	}
+
+	// 2024-05-15 is a Wednesday, 2024-05-18 a Saturday.
+	_, ok := Check(shift{Day: time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC), Rest: "2024-05-18"})
+	assert.True(t, ok)
+
+	errs, ok := Check(shift{Day: time.Date(2024, 5, 18, 0, 0, 0, 0, time.UTC), Rest: "2024-05-15"})
+	assert.False(t, ok)
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "排班日不在允许的星期内", errs[0].Error())
+
+	errs, _ = Check(struct {
+		Day time.Time `valid:"weekday:someday"`
+	}{})
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "Day检查规则入参错误", errs[0].Error())
+}
+
+func Test_Age(t *testing.T) {
+	fixClock(t, time.Date(2024, 5, 15, 13, 30, 0, 0, time.UTC))
+
+	type person struct {
+		Birthday time.Time `valid:"age:18,120" label:"生日"`
+		Parent   string    `valid:"age:21" label:"监护人生日"`
+	}
+
+	adult := person{
+		Birthday: time.Date(2006, 5, 15, 0, 0, 0, 0, time.UTC), // 18 today
+		Parent:   "1990-01-01",
+	}
+	_, ok := Check(adult)
+	assert.True(t, ok)
+
+	errs, ok := Check(person{
+		Birthday: time.Date(2006, 5, 16, 0, 0, 0, 0, time.UTC), // 18 tomorrow
+		Parent:   "2004-01-01",
+	})
+	assert.False(t, ok)
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "生日的年龄应在[18, 120]范围内", errs[0].Error())
+	assert.Equal(t, "监护人生日的年龄应在[21, +∞)范围内", errs[1].Error())
+
+	errs, _ = Check(struct {
+		Birthday time.Time `valid:"age:30,18"`
+	}{Birthday: adult.Birthday})
+	assert.Equal(t, 1, len(errs))
+}
@@ -0,0 +1,459 @@
+package govalid
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// TagError is a problem in the rule tag of a field.
+type TagError struct {
+	FieldPath string
+	Checker   string // Empty if the tag itself is malformed.
+	Err       error
+}
+
+func (e *TagError) Error() string {
+	if e.Checker == "" {
+		return fmt.Sprintf("%s: %v", e.FieldPath, e.Err)
+	}
+	return fmt.Sprintf("%s: %s: %v", e.FieldPath, e.Checker, e.Err)
+}
+
+// CompileError lists every problem Compile found in the tags of a type.
+type CompileError struct {
+	Type   reflect.Type
+	Errors []*TagError
+}
+
+func (e *CompileError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d invalid rules in %s:", len(e.Errors), e.Type)
+	for _, err := range e.Errors {
+		b.WriteString("\n\t")
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+// Compile checks the rule tags of a struct type, and of the structs and
+// struct slices it contains, the way Check would walk them. Against the
+// checker specs (see CheckerSpec), it reports malformed tags, unknown
+// checkers, checkers applied to fields of the wrong kind, wrong param
+// counts, params that don't parse or contradict each other, such as
+// between bounds in the wrong order, and field references that don't
+// resolve, all at once as a *CompileError. Call it from init() or a test
+// to catch them before Check meets them in production. For a slice type,
+// "$root." references resolve against its element type, as in Check.
+func Compile(typ reflect.Type) error {
+	if typ == nil {
+		return fmt.Errorf("compile: nil type")
+	}
+	root := typ
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	c := &compiler{root: root}
+	switch {
+	case typ.Kind() == reflect.Struct:
+		c.walk(typ, "", nil, []reflect.Type{typ})
+	case isNestedSlice(typ):
+		elem, _ := nestedStructType(typ.Elem())
+		c.walk(elem, "[]", nil, []reflect.Type{elem})
+	default:
+		return fmt.Errorf("compile %s: not a struct type", root)
+	}
+
+	if len(c.errs) > 0 {
+		return &CompileError{Type: root, Errors: c.errs}
+	}
+	return nil
+}
+
+// MustCompile is like Compile for the type of v, but panics if the tags
+// have problems.
+//
+// Example:
+//
+//	func init() {
+//		govalid.MustCompile(SignUpForm{})
+//	}
+func MustCompile(v interface{}) {
+	if err := Compile(reflect.TypeOf(v)); err != nil {
+		panic(err)
+	}
+}
+
+type compiler struct {
+	root reflect.Type
+	errs []*TagError
+}
+
+func (c *compiler) errorf(path, checker string, format string, args ...interface{}) {
+	c.errs = append(c.errs, &TagError{
+		FieldPath: path,
+		Checker:   checker,
+		Err:       fmt.Errorf(format, args...),
+	})
+}
+
+// walk checks the fields of the struct type typ at path. parents are the
+// enclosing struct types, outermost first, and scopes are the struct types
+// field references resolve against, the last for the fields of typ: it is
+// typ, or the struct embedding it.
+func (c *compiler) walk(typ reflect.Type, path string, parents, scopes []reflect.Type) {
+	// A recursive type is checked once, on its outermost occurrence.
+	for _, parent := range parents {
+		if parent == typ {
+			return
+		}
+	}
+	ancestors := append(parents[:len(parents):len(parents)], typ)
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		fieldPath := joinPath(path, field.Name)
+
+		if isNestedSlice(field.Type) {
+			elem, _ := nestedStructType(field.Type.Elem())
+			c.walk(elem, fieldPath+"[]", ancestors, append(scopes[:len(scopes):len(scopes)], elem))
+		}
+		if nested, ok := nestedStructType(field.Type); ok {
+			nestedScopes := scopes
+			if !field.Anonymous {
+				nestedScopes = append(scopes[:len(scopes):len(scopes)], nested)
+			}
+			c.walk(nested, fieldPath, ancestors, nestedScopes)
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+
+		var rules []*rule
+		for _, rawRules := range fieldRuleTags(typ, field) {
+			parsed, err := parseRules(rawRules)
+			if err != nil {
+				c.errs = append(c.errs, &TagError{FieldPath: fieldPath, Err: err})
+				continue
+			}
+			rules = append(rules, parsed...)
+		}
+		for _, r := range withTypeRules(field.Type, rules) {
+			c.rule(r, field.Type, fieldPath, scopes)
+		}
+	}
+}
+
+// rule checks a rule of a field of type fieldType with the reference
+// scopes of walk.
+func (c *compiler) rule(r *rule, fieldType reflect.Type, path string, scopes []reflect.Type) {
+	if r.op != ruleCheck {
+		for _, sub := range r.rules {
+			c.rule(sub, fieldType, path, scopes)
+		}
+		return
+	}
+
+	spec, ok := Describe(r.checker)
+	if !ok {
+		c.errorf(path, r.checker, "unknown checker")
+		return
+	}
+
+	if !appliesTo(spec.Kinds, fieldType) {
+		c.errorf(path, r.checker, "does not apply to %s fields", fieldType)
+		return
+	}
+
+	schema, n := spec.Params, len(r.params)
+	switch {
+	case schema.Variadic && (n < schema.Min || n%len(schema.Types) != 0):
+		if len(schema.Types) > 1 {
+			c.errorf(path, r.checker, "takes at least %d params in groups of %d, got %d", schema.Min, len(schema.Types), n)
+		} else {
+			c.errorf(path, r.checker, "takes at least %d params, got %d", schema.Min, n)
+		}
+		return
+	case schema.Variadic:
+	case schema.Max == 0 && n > 0:
+		c.errorf(path, r.checker, "takes no params, got %d", n)
+		return
+	case schema.Min == schema.Max && n != schema.Min:
+		c.errorf(path, r.checker, "takes %d params, got %d", schema.Min, n)
+		return
+	case n < schema.Min || n > schema.Max:
+		c.errorf(path, r.checker, "takes %d to %d params, got %d", schema.Min, schema.Max, n)
+		return
+	}
+
+	valid := true
+	for i, param := range r.params {
+		// The params of a RegisterParamFunc checker are parsed as its
+		// arguments, as in Check.
+		if len(spec.args) > 0 {
+			arg := spec.args[i%len(spec.args)]
+			if _, ok := funcParam(param, arg); !ok {
+				c.errorf(path, r.checker, "param %d %q: does not parse as %s", i+1, param, arg)
+				valid = false
+			}
+			continue
+		}
+		t := schema.Types[i%len(schema.Types)]
+		if err := c.param(t, param, fieldType, scopes); err != nil {
+			c.errorf(path, r.checker, "param %d %q: %v", i+1, param, err)
+			valid = false
+		}
+	}
+
+	if valid && spec.args == nil {
+		if err := paramRelations(r.checker, r.params, fieldType); err != nil {
+			c.errorf(path, r.checker, "%v", err)
+		}
+	}
+}
+
+// paramRelations checks the params of the built-in checkers that must
+// agree with each other, as Check does: a decimal's scale must fit its
+// precision, and the upper bound of age and between can't be below the
+// lower one. The params are known to parse.
+func paramRelations(checker string, params []string, fieldType reflect.Type) error {
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	switch checker {
+	case "decimal":
+		precision, _ := strconv.Atoi(strings.TrimSpace(params[0]))
+		scale, _ := strconv.Atoi(strings.TrimSpace(params[1]))
+		if precision < 1 {
+			return fmt.Errorf("precision %d is less than 1", precision)
+		}
+		if scale < 0 || scale > precision {
+			return fmt.Errorf("scale %d is not within [0, %d]", scale, precision)
+		}
+
+	case "age":
+		if len(params) == 2 {
+			minAge, _ := strconv.Atoi(params[0])
+			maxAge, _ := strconv.Atoi(params[1])
+			if maxAge < minAge {
+				return fmt.Errorf("max %d is less than min %d", maxAge, minAge)
+			}
+		}
+
+	case "between":
+		if boundsReversed(params[0], params[1], fieldType) {
+			return fmt.Errorf("upper bound %q is less than lower bound %q", params[1], params[0])
+		}
+	}
+	return nil
+}
+
+// boundsReversed reports whether the upper bound of between is below the
+// lower one for a field of type fieldType, reading them as Check does.
+func boundsReversed(lower, upper string, fieldType reflect.Type) bool {
+	if fieldType == durationType {
+		l, lowerErr := parseDuration(lower)
+		u, upperErr := parseDuration(upper)
+		return lowerErr == nil && upperErr == nil && u < l
+	}
+
+	l, lowerOK := parseDecimal(lower)
+	u, upperOK := parseDecimal(upper)
+	if lowerOK && upperOK && fieldType != timeType {
+		return u.Cmp(l) < 0
+	}
+
+	// Otherwise the bounds are times.
+	lowerTime, lowerErr := parseTimeParam(lower)
+	upperTime, upperErr := parseTimeParam(upper)
+	return lowerErr == nil && upperErr == nil && upperTime.Before(lowerTime)
+}
+
+// appliesTo reports whether a checker of the given kinds applies to a field
+// of type fieldType. Pointers are dereferenced, and interfaces and
+// driver.Valuer types are only known at runtime. The Struct kind stands for
+// leaf types such as time.Time and big.Int: other structs are walked field
+// by field rather than checked as values.
+func appliesTo(kinds []reflect.Kind, fieldType reflect.Type) bool {
+	if len(kinds) == 0 || fieldType.Implements(valuerType) {
+		return true
+	}
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+		if fieldType.Implements(valuerType) {
+			return true
+		}
+	}
+	if fieldType.Kind() == reflect.Interface {
+		return true
+	}
+	if fieldType.Kind() == reflect.Struct && !isLeafType(fieldType) {
+		return false
+	}
+	for _, kind := range kinds {
+		if fieldType.Kind() == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// param checks a param of type t of a field of type fieldType with the
+// reference scopes of walk.
+func (c *compiler) param(t ParamType, param string, fieldType reflect.Type, scopes []reflect.Type) error {
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	switch t {
+	case ParamInt:
+		if _, err := strconv.Atoi(param); err != nil {
+			return fmt.Errorf("not an integer")
+		}
+
+	case ParamFloat:
+		if _, err := strconv.ParseFloat(param, 64); err != nil {
+			return fmt.Errorf("not a number")
+		}
+
+	case ParamBool:
+		if _, err := strconv.ParseBool(param); err != nil {
+			return fmt.Errorf("not a bool")
+		}
+
+	case ParamDuration:
+		if _, err := parseDuration(param); err != nil {
+			return fmt.Errorf("not a duration")
+		}
+
+	case ParamNumber:
+		return numberParam(param, fieldType)
+
+	case ParamBound:
+		if fieldType == timeType {
+			_, err := parseTimeParam(param)
+			return err
+		}
+		if fieldType.Kind() == reflect.String {
+			if _, ok := parseDecimal(param); ok {
+				return nil
+			}
+			if _, err := parseTimeParam(param); err != nil {
+				return fmt.Errorf("neither a number nor a time")
+			}
+			return nil
+		}
+		return numberParam(param, fieldType)
+
+	case ParamTime:
+		_, err := parseTimeParam(param)
+		return err
+
+	case ParamField:
+		if !c.lookupFieldType(param, scopes) {
+			return fmt.Errorf("no such field")
+		}
+
+	case ParamRegex:
+		_, err := compileRegex(param)
+		return err
+
+	case ParamPattern:
+		if _, ok := patterns[param]; !ok {
+			return fmt.Errorf("pattern not registered")
+		}
+
+	case ParamWeekday:
+		if _, ok := weekdays[strings.ToLower(param)]; !ok {
+			return fmt.Errorf("not a weekday")
+		}
+
+	case ParamLayout:
+		if param == "" {
+			return fmt.Errorf("empty layout")
+		}
+	}
+	return nil
+}
+
+// numberParam checks that param is a number that a field of type
+// fieldType can be compared with.
+func numberParam(param string, fieldType reflect.Type) error {
+	var err error
+	switch {
+	case fieldType == durationType:
+		_, err = parseDuration(param)
+	case isIntKind(fieldType.Kind()):
+		_, err = strconv.ParseInt(param, 10, 64)
+	case isUintKind(fieldType.Kind()):
+		_, err = strconv.ParseUint(param, 10, 64)
+	case isFloatKind(fieldType.Kind()):
+		_, err = strconv.ParseFloat(param, 64)
+	default:
+		if _, ok := parseDecimal(param); !ok {
+			return fmt.Errorf("not a number")
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("not a number of type %s", fieldType)
+	}
+	return nil
+}
+
+// lookupFieldType is CheckerContext.LookupField for types: it reports
+// whether ref resolves from a field with the reference scopes of walk.
+func (c *compiler) lookupFieldType(ref string, scopes []reflect.Type) bool {
+	switch {
+	case strings.HasPrefix(ref, RootFieldPrefix):
+		root := c.root
+		for root.Kind() == reflect.Ptr {
+			root = root.Elem()
+		}
+		if root.Kind() == reflect.Slice {
+			root = root.Elem()
+		}
+		return resolveFieldType(root, strings.TrimPrefix(ref, RootFieldPrefix))
+	case strings.HasPrefix(ref, "../"):
+		for strings.HasPrefix(ref, "../") {
+			if len(scopes) < 2 {
+				return false
+			}
+			scopes = scopes[:len(scopes)-1]
+			ref = strings.TrimPrefix(ref, "../")
+		}
+		return resolveFieldType(scopes[len(scopes)-1], ref)
+	}
+
+	// Like in Check, a plain reference falls back to the enclosing structs.
+	for i := len(scopes) - 1; i >= 0; i-- {
+		if resolveFieldType(scopes[i], ref) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveFieldType reports whether the dotted path ref resolves from the
+// struct type current.
+func resolveFieldType(current reflect.Type, ref string) bool {
+	for _, name := range strings.Split(ref, ".") {
+		for current.Kind() == reflect.Ptr {
+			current = current.Elem()
+		}
+		if current.Kind() != reflect.Struct {
+			return false
+		}
+		field, ok := current.FieldByName(name)
+		if !ok || field.PkgPath != "" {
+			return false
+		}
+		current = field.Type
+	}
+	return true
+}
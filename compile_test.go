@@ -0,0 +1,213 @@
+package govalid
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type compileAddress struct {
+	City string `valid:"required;minlen:2"`
+	Zip  string `valid:"required_with:City"`
+}
+
+type compileItem struct {
+	Price    float64 `valid:"min:0;max:99.5"`
+	Currency string  `valid:"equal:../Currency"`
+}
+
+type compileOrder struct {
+	Currency string         `valid:"list:CNY,USD"`
+	Email    string         `valid:"omitempty;email;regex:^[^;]+$"`
+	Timeout  time.Duration  `valid:"max:30s"`
+	StartAt  time.Time      `valid:"after:now;weekday:mon,fri"`
+	EndAt    *time.Time     `valid:"gtfield:StartAt;between:today,now+30d"`
+	Score    string         `valid:"between:0,100"`
+	Address  compileAddress `valid:"required"`
+	Items    []compileItem
+	internal string `valid:"nonsense"`
+}
+
+func Test_Compile_Valid(t *testing.T) {
+	assert.Nil(t, Compile(reflect.TypeOf(compileOrder{})))
+	assert.Nil(t, Compile(reflect.TypeOf(&compileOrder{})))
+	assert.Nil(t, Compile(reflect.TypeOf([]compileAddress{})))
+	assert.NotPanics(t, func() { MustCompile(compileOrder{}) })
+}
+
+func Test_Compile_ReportsEveryProblem(t *testing.T) {
+	type line struct {
+		Qty  int    `valid:"min:abc"`
+		Note string `valid:"equal:../Nope"`
+	}
+	type form struct {
+		Name     string        `valid:"requird;maxlen:"`
+		Role     string        `valid:"list:'admin"`
+		Age      int           `valid:"min:1.5;max:120;age:1,2,3"`
+		Email    string        `valid:"email:strict"`
+		Timeout  time.Duration `valid:"max:soon"`
+		Birthday time.Time     `valid:"before:yesterday;weekday:funday;age:1,2,3"`
+		Phone    string        `valid:"required_if:Kind"`
+		Code     string        `valid:"regex:([a-z];pattern:nope"`
+		Lines    []line
+	}
+
+	err := Compile(reflect.TypeOf(form{}))
+	compileErr, ok := err.(*CompileError)
+	if !assert.True(t, ok, "%T", err) {
+		return
+	}
+
+	var got []string
+	for _, e := range compileErr.Errors {
+		got = append(got, e.Error())
+	}
+	assert.Equal(t, []string{
+		`Name: parse rules "requird;maxlen:": missing parameter, quote an empty one as '' at offset 15`,
+		`Role: parse rules "list:'admin": unterminated quoted parameter at offset 5`,
+		`Age: min: param 1 "1.5": not a number of type int`,
+		`Age: age: does not apply to int fields`,
+		`Email: email: takes no params, got 1`,
+		`Timeout: max: param 1 "soon": not a number of type time.Duration`,
+		`Birthday: before: param 1 "yesterday": parsing time "yesterday" as "2006-01-02": cannot parse "yesterday" as "2006"`,
+		`Birthday: weekday: param 1 "funday": not a weekday`,
+		`Birthday: age: takes 1 to 2 params, got 3`,
+		`Phone: required_if: takes at least 2 params in groups of 2, got 1`,
+		`Code: regex: param 1 "([a-z];pattern:nope": error parsing regexp: missing closing ): ` + "`([a-z];pattern:nope`",
+		`Lines[].Qty: min: param 1 "abc": not a number of type int`,
+		`Lines[].Note: equal: param 1 "../Nope": no such field`,
+	}, got)
+	assert.Contains(t, err.Error(), "13 invalid rules in govalid.form:")
+}
+
+func Test_Compile_ParamRelations(t *testing.T) {
+	type form struct {
+		Price    string        `valid:"decimal:0,0;decimal:4,5;decimal:12,2"`
+		Birthday time.Time     `valid:"age:65,18;age:18,65;between:2030-01-01,2020-01-01"`
+		Score    int           `valid:"between:10,1;between:1,10"`
+		Rate     string        `valid:"between:1.5,0.5"`
+		Timeout  time.Duration `valid:"between:1m,30s"`
+		Window   string        `valid:"between:now,today-1d"`
+	}
+
+	err := Compile(reflect.TypeOf(form{}))
+	compileErr, ok := err.(*CompileError)
+	if !assert.True(t, ok, "%T", err) {
+		return
+	}
+	var got []string
+	for _, e := range compileErr.Errors {
+		got = append(got, e.Error())
+	}
+	assert.Equal(t, []string{
+		`Price: decimal: precision 0 is less than 1`,
+		`Price: decimal: scale 5 is not within [0, 4]`,
+		`Birthday: age: max 18 is less than min 65`,
+		`Birthday: between: upper bound "2020-01-01" is less than lower bound "2030-01-01"`,
+		`Score: between: upper bound "1" is less than lower bound "10"`,
+		`Rate: between: upper bound "0.5" is less than lower bound "1.5"`,
+		`Timeout: between: upper bound "30s" is less than lower bound "1m"`,
+		`Window: between: upper bound "today-1d" is less than lower bound "now"`,
+	}, got)
+}
+
+func Test_Compile_StructKinds(t *testing.T) {
+	type form struct {
+		Price   *big.Int       `valid:"min:0"`
+		StartAt time.Time      `valid:"between:2020-01-01,2030-01-01"`
+		Address compileAddress `valid:"min:1;between:0,1"`
+	}
+
+	// Structs other than leaf types are walked, not compared.
+	err := Compile(reflect.TypeOf(form{}))
+	compileErr, ok := err.(*CompileError)
+	if assert.True(t, ok, "%T", err) {
+		assert.Equal(t, 2, len(compileErr.Errors))
+		assert.Equal(t, "Address: min: does not apply to govalid.compileAddress fields", compileErr.Errors[0].Error())
+	}
+}
+
+func Test_Compile_UnknownChecker(t *testing.T) {
+	type form struct {
+		Name string `valid:"required;nickname"`
+	}
+
+	err := Compile(reflect.TypeOf(form{}))
+	assert.EqualError(t, err, "1 invalid rules in govalid.form:\n\tName: nickname: unknown checker")
+
+	Checkers["nickname"] = func(c CheckerContext) *ErrContext { return nil }
+	defer delete(Checkers, "nickname")
+	assert.Nil(t, Compile(reflect.TypeOf(form{})))
+}
+
+func Test_Compile_FieldReferences(t *testing.T) {
+	type inner struct {
+		A string `valid:"equal:$root.Top"`
+		B string `valid:"equal:../Top"`
+		C string `valid:"equal:../../Top"`
+		D string `valid:"equal:$root.Inner.A"`
+	}
+	type outer struct {
+		Top   string
+		Inner inner
+	}
+
+	err := Compile(reflect.TypeOf(outer{}))
+	compileErr, ok := err.(*CompileError)
+	if assert.True(t, ok, "%T", err) {
+		assert.Equal(t, 1, len(compileErr.Errors))
+		assert.Equal(t, "Inner.C", compileErr.Errors[0].FieldPath)
+		assert.Equal(t, "equal", compileErr.Errors[0].Checker)
+	}
+}
+
+func Test_Compile_EnclosingReferences(t *testing.T) {
+	type Credentials struct {
+		Confirm string `valid:"equal:Password"`
+		Echo    string `valid:"equal:../Top"`
+	}
+	type inner struct {
+		Credentials
+		Repeat string `valid:"equal:Password"`
+		Missed string `valid:"equal:Nope"`
+	}
+	type outer struct {
+		Top      string
+		Password string
+		Inner    inner
+	}
+
+	err := Compile(reflect.TypeOf(outer{}))
+	compileErr, ok := err.(*CompileError)
+	if assert.True(t, ok, "%T", err) {
+		assert.Equal(t, 1, len(compileErr.Errors))
+		assert.Equal(t, "Inner.Missed", compileErr.Errors[0].FieldPath)
+	}
+}
+
+func Test_Compile_SliceRootReferences(t *testing.T) {
+	type line struct {
+		Region string
+		Items  []struct {
+			Region string `valid:"equal:$root.Region"`
+		}
+	}
+	assert.Nil(t, Compile(reflect.TypeOf([]line{})))
+}
+
+func Test_Compile_RecursiveType(t *testing.T) {
+	type node struct {
+		Name     string `valid:"required"`
+		Children []node
+	}
+	assert.Nil(t, Compile(reflect.TypeOf(node{})))
+}
+
+func Test_Compile_NotAStruct(t *testing.T) {
+	assert.NotNil(t, Compile(reflect.TypeOf("")))
+	assert.NotNil(t, Compile(nil))
+	assert.Panics(t, func() { MustCompile(42) })
+}
@@ -0,0 +1,137 @@
+package govalid
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// =============================================================================
+// OR groups
+// =============================================================================
+
+func Test_Check_AnyOf(t *testing.T) {
+	type form struct {
+		Contact string `valid:"required;email|mobile" label:"联系方式"`
+	}
+
+	for _, contact := range []string{"e99@example.com", "13388886666"} {
+		_, ok := Check(form{Contact: contact})
+		assert.True(t, ok, contact)
+	}
+
+	errs, ok := Check(form{Contact: "nope"})
+	assert.False(t, ok)
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "联系方式不是合法的电子邮箱格式或不是合法的手机号", errs[0].Error())
+
+	errs, _ = Check(form{Contact: "nope"}, language.English)
+	assert.Equal(t, "联系方式 is not a valid email address or is not a valid mobile phone number", errs[0].Error())
+
+	// The empty string is left to required.
+	errs, _ = Check(form{})
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "联系方式不能为空", errs[0].Error())
+}
+
+func Test_Check_AnyOf_OwnTemplate(t *testing.T) {
+	SetMessageTemplates(map[string]string{"email|mobile": "应为邮箱或手机号"})
+	SetMessageTemplates(map[string]string{"email|mobile": " must be an email or mobile number"}, language.English)
+	defer RemoveMessageTemplate("email|mobile")
+	defer RemoveMessageTemplate("email|mobile", language.English)
+
+	// Whitespace doesn't change the group's key.
+	type form struct {
+		Contact string `valid:"email | mobile" label:"联系方式"`
+	}
+
+	errs, _ := Check(form{Contact: "nope"})
+	assert.Equal(t, "联系方式应为邮箱或手机号", errs[0].Error())
+
+	errs, _ = Check(form{Contact: "nope"}, language.English)
+	assert.Equal(t, "联系方式 must be an email or mobile number", errs[0].Error())
+}
+
+func Test_Check_AnyOf_Groups(t *testing.T) {
+	type form struct {
+		Phone string `valid:"(tel|mobile);maxlen:12" label:"电话"`
+		Code  string `valid:"(alpha;minlen:3)|list:n/a" label:"编码"`
+	}
+
+	_, ok := Check(form{Phone: "010-88886666", Code: "abc"})
+	assert.True(t, ok)
+	_, ok = Check(form{Phone: "13388886666", Code: "n/a"})
+	assert.True(t, ok)
+
+	errs, _ := Check(form{Phone: "x", Code: "ab"})
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "电话不是合法的座机号码或不是合法的手机号", errs[0].Error())
+	assert.Equal(t, "编码长度应大于3或不是一个有效的值", errs[1].Error())
+}
+
+func Test_Check_AnyOf_InternalErrors(t *testing.T) {
+	type form struct {
+		A string `valid:"email|nope" label:"A"`
+		B string `valid:"email|minlen:x" label:"B"`
+	}
+
+	_, ok := Check(form{A: "a@example.com", B: "a@example.com"})
+	assert.True(t, ok)
+
+	errs, _ := Check(form{A: "x", B: "x"})
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "A检查规则未找到", errs[0].Error())
+	assert.Equal(t, "B检查规则入参错误", errs[1].Error())
+}
+
+// =============================================================================
+// Negation
+// =============================================================================
+
+func Test_Check_Not(t *testing.T) {
+	type form struct {
+		Username string `valid:"not:list:admin,root" label:"用户名"`
+		Host     string `valid:"not:(ipv4|list:localhost)" label:"主机"`
+	}
+
+	_, ok := Check(form{Username: "alice", Host: "example.com"})
+	assert.True(t, ok)
+	_, ok = Check(form{})
+	assert.True(t, ok)
+
+	errs, _ := Check(form{Username: "root", Host: "127.0.0.1"})
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "用户名不允许使用该值", errs[0].Error())
+	assert.Equal(t, "主机不允许使用该值", errs[1].Error())
+
+	errs, _ = Check(form{Username: "admin"}, language.English)
+	assert.Equal(t, "用户名 is not allowed", errs[0].Error())
+
+	SetMessageTemplates(map[string]string{"not:list:admin,root": "不能使用保留名称"})
+	defer RemoveMessageTemplate("not:list:admin,root")
+	errs, _ = Check(form{Username: "admin"})
+	assert.Equal(t, "用户名不能使用保留名称", errs[0].Error())
+
+	errs, _ = Check(struct {
+		N string `valid:"not:min:x" label:"N"`
+	}{N: "5"})
+	assert.Equal(t, "N检查规则入参错误", errs[0].Error())
+}
+
+func Test_Compile_Composite(t *testing.T) {
+	assert.Nil(t, Compile(reflect.TypeOf(struct {
+		Contact string `valid:"email|mobile;not:list:admin"`
+	}{})))
+
+	err := Compile(reflect.TypeOf(struct {
+		Contact string `valid:"email|mobil;not:(maxlen:x|ipv4)"`
+	}{}))
+	if assert.NotNil(t, err) {
+		errs := err.(*CompileError).Errors
+		assert.Equal(t, 2, len(errs))
+		assert.Equal(t, "mobil", errs[0].Checker)
+		assert.Equal(t, "maxlen", errs[1].Checker)
+	}
+}
@@ -0,0 +1,114 @@
+package govalid
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+type tenantKey struct{}
+
+type contextValidateForm struct {
+	Name string `valid:"required" label:"名称"`
+}
+
+func (f contextValidateForm) Validate(ctx context.Context) error {
+	if ctx.Value(tenantKey{}) != "acme" {
+		return errors.New("unknown tenant")
+	}
+	return nil
+}
+
+func Test_CheckContext_CheckerSeesContext(t *testing.T) {
+	const checkerName = "tenantOnly"
+	defer delete(Checkers, checkerName)
+
+	Checkers[checkerName] = func(c CheckerContext) *ErrContext {
+		if c.Context.Value(tenantKey{}) != c.FieldValue {
+			return MakeUserDefinedError("wrong tenant")
+		}
+		return nil
+	}
+
+	v := struct {
+		Tenant string `valid:"tenantOnly"`
+	}{Tenant: "acme"}
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+	errs, err := CheckContext(ctx, v)
+	assert.NoError(t, err)
+	assert.Empty(t, errs)
+
+	ctx = context.WithValue(context.Background(), tenantKey{}, "other")
+	errs, err = CheckContext(ctx, v)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "wrong tenant", errs[0].Error())
+
+	t.Run("Check uses the background context", func(t *testing.T) {
+		_, ok := Check(v)
+		assert.False(t, ok)
+	})
+}
+
+func Test_CheckContext_ValidateWithContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+	errs, err := CheckContext(ctx, contextValidateForm{Name: "x"})
+	assert.NoError(t, err)
+	assert.Empty(t, errs)
+
+	errs, err = CheckContext(context.Background(), &contextValidateForm{Name: "x"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "unknown tenant", errs[0].Error())
+}
+
+func Test_CheckContext_Cancelled(t *testing.T) {
+	const checkerName = "cancelAfterFirst"
+	defer delete(Checkers, checkerName)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	calls := 0
+	Checkers[checkerName] = func(c CheckerContext) *ErrContext {
+		calls++
+		cancel()
+		return NewErrorContext(c)
+	}
+
+	v := struct {
+		A string `valid:"cancelAfterFirst"`
+		B string `valid:"cancelAfterFirst"`
+	}{}
+	errs, err := CheckContext(ctx, v)
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 1, len(errs))
+
+	t.Run("already cancelled", func(t *testing.T) {
+		errs, err := CheckContext(ctx, contextValidateForm{})
+		assert.Equal(t, context.Canceled, err)
+		assert.Empty(t, errs)
+	})
+}
+
+func Test_CheckContext_Options(t *testing.T) {
+	type item struct {
+		Name string `valid:"required" label:"名称" label-en:"name"`
+	}
+	v := struct {
+		Items []item `label:"商品" label-en:"Item"`
+	}{Items: []item{{}}}
+
+	errs, err := CheckContext(context.Background(), v, WithLanguage(language.English))
+	assert.NoError(t, err)
+	assert.Equal(t, "name can not be empty", errs[0].Error())
+
+	errs, err = CheckContext(context.Background(), v, WithHierarchicalLabels(true))
+	assert.NoError(t, err)
+	assert.Equal(t, "第1个商品的名称不能为空", errs[0].Error())
+}
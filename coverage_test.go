@@ -14,11 +14,11 @@ import (
 
 func Test_SetMessageTemplates_NewLocale(t *testing.T) {
 	// Pick a locale that the package doesn't ship templates for.
-	loc := language.Japanese
+	loc := language.Vietnamese
 	defer delete(errorTemplateSet, loc)
 
 	SetMessageTemplates(map[string]string{
-		"required": " は必須です",
+		"required": " không được để trống",
 	}, loc)
 
 	v := struct {
@@ -26,7 +26,7 @@ func Test_SetMessageTemplates_NewLocale(t *testing.T) {
 	}{}
 	errs, ok := Check(v, loc)
 	assert.False(t, ok)
-	assert.Equal(t, "Name は必須です", errs[0].Error())
+	assert.Equal(t, "Name không được để trống", errs[0].Error())
 }
 
 // =============================================================================
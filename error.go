@@ -12,6 +12,7 @@ var _ error = (*ErrContext)(nil)
 // ErrContext contains the error context.
 type ErrContext struct {
 	FieldName        string
+	FieldPath        string
 	FieldLabel       string
 	FieldValue       interface{}
 	TemplateLanguage language.Tag
@@ -19,6 +20,9 @@ type ErrContext struct {
 	fieldLimitValue interface{}
 	errorTemplate   string
 	errorMessage    string
+	// internal is set for errors in the rules rather than the field, such
+	// as param errors, which negation and OR groups pass on.
+	internal bool
 }
 
 func (e *ErrContext) Error() string {
@@ -34,25 +38,44 @@ var (
 
 // errorTemplateSet is the set of error templates for i18n purpose.
 var errorTemplateSet = map[language.Tag]map[string]string{
-	language.Chinese: errorTemplateChinese,
-	language.English: errorTemplateEnglish,
+	language.Chinese:            errorTemplateChinese,
+	language.TraditionalChinese: errorTemplateTraditionalChinese,
+	language.English:            errorTemplateEnglish,
+	language.Japanese:           errorTemplateJapanese,
+	language.Korean:             errorTemplateKorean,
+	language.French:             errorTemplateFrench,
+	language.German:             errorTemplateGerman,
+	language.Spanish:            errorTemplateSpanish,
+	language.Russian:            errorTemplateRussian,
 }
 
 // NewErrorContext return a error context.
 func NewErrorContext(c CheckerContext) *ErrContext {
 	errCtx := &ErrContext{
 		FieldName:        c.FieldName,
+		FieldPath:        c.FieldPath,
 		FieldLabel:       c.FieldLabel,
 		FieldValue:       c.FieldValue,
 		TemplateLanguage: c.TemplateLanguage,
 
-		errorTemplate: getErrorTemplate(c.Rule.checker, c.TemplateLanguage),
+		errorTemplate: getErrorTemplate(messageKey(c.Rule), c.TemplateLanguage),
 	}
 	errCtx.makeMessage()
 
 	return errCtx
 }
 
+// messageKey returns the key of the template of the rule's errors: the
+// message key of its checker's spec, or the rule's own text.
+func messageKey(r *rule) string {
+	if r.op == ruleCheck {
+		if spec, ok := checkerSpecs[r.checker]; ok && spec.MessageKey != "" {
+			return spec.MessageKey
+		}
+	}
+	return r.checker
+}
+
 func (e *ErrContext) makeMessage() {
 	msg := e.errorTemplate
 	if strings.Contains(e.errorTemplate, FieldNamePlaceholder) || strings.Contains(e.errorTemplate, FieldLimitPlaceholder) {
@@ -94,12 +117,24 @@ func (e *ErrContext) SetTemplate(key string) {
 	e.makeMessage()
 }
 
+// lookupTemplateSet returns the template set registered for the given
+// language. Regional variants such as "en-US" or "zh-TW" walk up to their
+// parent tag ("en", "zh-Hant") before falling back to the default language.
+func lookupTemplateSet(templateLanguage language.Tag) map[string]string {
+	for tag := templateLanguage; ; tag = tag.Parent() {
+		if errorTemplate, ok := errorTemplateSet[tag]; ok {
+			return errorTemplate
+		}
+		if tag.IsRoot() {
+			break
+		}
+	}
+	return errorTemplateSet[defaultTemplateLanguage]
+}
+
 // getErrorTemplate return the template of the given rule name.
 func getErrorTemplate(key string, templateLanguage language.Tag) string {
-	errorTemplate, ok := errorTemplateSet[templateLanguage]
-	if !ok {
-		errorTemplate = errorTemplateSet[defaultTemplateLanguage]
-	}
+	errorTemplate := lookupTemplateSet(templateLanguage)
 
 	if value, ok := errorTemplate[key]; ok {
 		return value
@@ -119,24 +154,98 @@ func MakeCheckerNotFoundError(c CheckerContext) *ErrContext {
 
 	errCtx := &ErrContext{
 		FieldName:       c.FieldName,
+		FieldPath:       c.FieldPath,
 		FieldLabel:      c.FieldLabel,
 		FieldValue:      c.FieldValue,
 		fieldLimitValue: c.Rule.params,
 		errorTemplate:   template,
+		internal:        true,
+	}
+	errCtx.makeMessage()
+	return errCtx
+}
+
+// MakeRuleSyntaxError reports the malformed rule tag of a field. The
+// message gives the column of the error when err is a *ParseError.
+func MakeRuleSyntaxError(c CheckerContext, err error) *ErrContext {
+	template := strings.TrimPrefix(getErrorTemplate("_syntaxError", c.TemplateLanguage), "~")
+
+	column := 0
+	if parseErr, ok := err.(*ParseError); ok {
+		column = parseErr.Offset + 1
+	}
+
+	errCtx := &ErrContext{
+		FieldName:       c.FieldName,
+		FieldPath:       c.FieldPath,
+		FieldLabel:      c.FieldLabel,
+		FieldValue:      c.FieldValue,
+		fieldLimitValue: column,
+		errorTemplate:   template,
+		internal:        true,
 	}
 	errCtx.makeMessage()
 	return errCtx
 }
 
+// makeAnyOfError reports a field rejected by every alternative of an OR
+// group, which returned errs. It uses the group's own template if there is
+// one, e.g. for "email|mobile", and joins the messages of errs with the
+// "_or" template otherwise.
+func makeAnyOfError(c CheckerContext, errs []*ErrContext) *ErrContext {
+	if _, ok := lookupTemplateSet(c.TemplateLanguage)[c.Rule.checker]; ok {
+		return NewErrorContext(c)
+	}
+
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.unlabelledMessage()
+	}
+	errCtx := &ErrContext{
+		FieldName:        c.FieldName,
+		FieldPath:        c.FieldPath,
+		FieldLabel:       c.FieldLabel,
+		FieldValue:       c.FieldValue,
+		TemplateLanguage: c.TemplateLanguage,
+		fieldLimitValue:  strings.Join(messages, getErrorTemplate("_or", c.TemplateLanguage)),
+		errorTemplate:    FieldLimitPlaceholder + "}}",
+	}
+	errCtx.makeMessage()
+	return errCtx
+}
+
+// makeNotError reports a field accepted by the negated rule of a "not:"
+// rule. It uses the rule's own template if there is one, e.g. for
+// "not:list:admin,root", and the generic "_not" template otherwise, since
+// the negated rule is tag syntax rather than a message.
+func makeNotError(c CheckerContext) *ErrContext {
+	errCtx := NewErrorContext(c)
+	if _, ok := lookupTemplateSet(c.TemplateLanguage)[c.Rule.checker]; !ok {
+		errCtx.SetTemplate("_not")
+	}
+	return errCtx
+}
+
+// unlabelledMessage returns the message of e without the field label, to
+// be joined with other messages about the same field.
+func (e *ErrContext) unlabelledMessage() string {
+	unlabelled := *e
+	unlabelled.FieldLabel = ""
+	unlabelled.makeMessage()
+	return unlabelled.errorMessage
+}
+
 func MakeCheckerParamError(c CheckerContext) *ErrContext {
 	template := strings.TrimPrefix(getErrorTemplate("_paramError", c.TemplateLanguage), "~")
 
 	errCtx := &ErrContext{
 		FieldName:       c.FieldName,
+		FieldPath:       c.FieldPath,
 		FieldLabel:      c.FieldLabel,
 		FieldValue:      c.FieldValue,
 		fieldLimitValue: c.Rule.params,
 		errorTemplate:   template,
+		internal:        true,
 	}
 	errCtx.makeMessage()
 	return errCtx
@@ -147,10 +256,12 @@ func MakeValueTypeError(c CheckerContext) *ErrContext {
 
 	errCtx := &ErrContext{
 		FieldName:       c.FieldName,
+		FieldPath:       c.FieldPath,
 		FieldLabel:      c.FieldLabel,
 		FieldValue:      c.FieldValue,
 		fieldLimitValue: c.Rule.params,
 		errorTemplate:   template,
+		internal:        true,
 	}
 	errCtx.makeMessage()
 	return errCtx
@@ -161,10 +272,12 @@ func MakeFieldNotFoundError(c CheckerContext) *ErrContext {
 
 	errCtx := &ErrContext{
 		FieldName:       c.FieldName,
+		FieldPath:       c.FieldPath,
 		FieldLabel:      c.FieldLabel,
 		FieldValue:      c.FieldValue,
 		fieldLimitValue: c.Rule.params,
 		errorTemplate:   template,
+		internal:        true,
 	}
 	errCtx.makeMessage()
 	return errCtx
@@ -113,8 +113,8 @@ func Test_ErrContext_Mutators(t *testing.T) {
 
 func Test_getErrorTemplate_Fallbacks(t *testing.T) {
 	t.Run("unknown language falls back to default chinese", func(t *testing.T) {
-		// Korean isn't registered; fall back to defaultTemplateLanguage.
-		got := getErrorTemplate("required", language.Korean)
+		// Vietnamese isn't registered; fall back to defaultTemplateLanguage.
+		got := getErrorTemplate("required", language.Vietnamese)
 		assert.Equal(t, "不能为空", got)
 	})
 
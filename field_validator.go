@@ -0,0 +1,58 @@
+package govalid
+
+import "reflect"
+
+// FieldValidator is implemented by types that validate their own values
+// wherever they are used as a field, such as a Money or SKU type. The
+// field's ValidateField runs after the rules of its tag, unless an
+// omitempty rule skipped them, and a nil pointer field is not validated.
+//
+// c describes the field as for a checker, with the "_invalid" template as
+// its rule, so that NewErrorContext(c) reports that the field is not
+// valid with the field's label and language. SetTemplate picks another
+// template.
+//
+// Example:
+//
+//	func (m Money) ValidateField(c govalid.CheckerContext) *govalid.ErrContext {
+//		if m%100 != 0 {
+//			return govalid.NewErrorContext(c)
+//		}
+//		return nil
+//	}
+type FieldValidator interface {
+	ValidateField(c CheckerContext) *ErrContext
+}
+
+// invalidRule is the rule of the checker context of a FieldValidator.
+var invalidRule = &rule{checker: "_invalid"}
+
+// fieldValidatorOf returns the FieldValidator of a field value, if its type
+// implements it with a value or pointer receiver.
+func fieldValidatorOf(v reflect.Value) (FieldValidator, bool) {
+	if !v.CanInterface() {
+		return nil, false
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil, false
+		}
+	}
+	if validator, ok := v.Interface().(FieldValidator); ok {
+		return validator, true
+	}
+	if v.Kind() == reflect.Interface || !reflect.PtrTo(v.Type()).Implements(fieldValidatorType) {
+		return nil, false
+	}
+	// A pointer receiver needs an addressable value, which a struct passed
+	// to Check by value doesn't have.
+	if !v.CanAddr() {
+		copied := reflect.New(v.Type()).Elem()
+		copied.Set(v)
+		v = copied
+	}
+	return v.Addr().Interface().(FieldValidator), true
+}
+
+var fieldValidatorType = reflect.TypeOf((*FieldValidator)(nil)).Elem()
@@ -0,0 +1,78 @@
+package govalid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// testCents must be a whole number of yuan.
+type testCents int64
+
+func (m testCents) ValidateField(c CheckerContext) *ErrContext {
+	if m%100 != 0 {
+		return NewErrorContext(c)
+	}
+	return nil
+}
+
+// testSKU validates itself with a pointer receiver and its own template.
+type testSKU string
+
+func (s *testSKU) ValidateField(c CheckerContext) *ErrContext {
+	if !strings.HasPrefix(string(*s), "SKU-") {
+		errCtx := NewErrorContext(c)
+		errCtx.SetTemplate("regex")
+		return errCtx
+	}
+	return nil
+}
+
+func Test_FieldValidator(t *testing.T) {
+	type item struct {
+		SKU testSKU `label:"货号"`
+	}
+	type form struct {
+		Price    testCents  `valid:"min:0" label:"价格"`
+		Discount *testCents `label:"折扣"`
+		Items    []item
+	}
+
+	_, ok := Check(form{Price: 100, Items: []item{{"SKU-1"}}})
+	assert.True(t, ok)
+
+	// The type's validation runs after the tag's rules.
+	discount := testCents(50)
+	errs, _ := Check(form{Price: -50, Discount: &discount, Items: []item{{"SKU-1"}, {"1"}}})
+	assert.Equal(t, 4, len(errs))
+	assert.Equal(t, "价格应大于0", errs[0].Error())
+	assert.Equal(t, "价格不是一个有效的值", errs[1].Error())
+	assert.Equal(t, "折扣不是一个有效的值", errs[2].Error())
+	assert.Equal(t, "Items[1].SKU", errs[3].FieldPath)
+	assert.Equal(t, "货号格式不正确", errs[3].Error())
+
+	// Pointer receivers work on addressable fields too.
+	errs, _ = Check(&form{Items: []item{{"1"}}}, language.English)
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "货号 is not in the correct format", errs[0].Error())
+}
+
+func Test_FieldValidator_Skipped(t *testing.T) {
+	type form struct {
+		Price testCents `valid:"omitempty" label:"价格"`
+		Tip   testCents `msg:"小费须为整元"`
+		Bad   testCents `valid:"min:" label:"坏"`
+	}
+
+	errs, _ := Check(form{Tip: 1, Bad: 1})
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "小费须为整元", errs[0].Error())
+	assert.Equal(t, "Bad", errs[1].FieldName)
+	assert.Equal(t, "坏检查规则在第5个字符处格式错误", errs[1].Error())
+
+	errs, _ = Check(form{Price: 1})
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "价格不是一个有效的值", errs[0].Error())
+}
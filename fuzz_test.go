@@ -33,6 +33,15 @@ func FuzzCheck(f *testing.F) {
 		"idcard",
 		"equal:Other",
 		"list:a,b,c",
+		"before:now+24h",
+		"between:today-1y,2030-01-01",
+		"datetime:2006-01-02",
+		"weekday:mon,5",
+		"age:18,120",
+		"regex:^[a-z]+(,[a-z]+)*;?$",
+		"regex:'^a;b$';maxlen:3",
+		"list:'a,b','it''s'",
+		"pattern:sku",
 		"unknown:foo,bar",
 		"required;email",
 		"min:abc",
@@ -42,6 +51,12 @@ func FuzzCheck(f *testing.F) {
 		"::::",
 		"required:",
 		":foo",
+		"email|mobile",
+		"not:list:admin,root",
+		"(tel|mobile);maxlen:20",
+		"not:(email|ipv4)",
+		"(omitempty;email)|list:n/a",
+		"((a|b",
 	}
 	for _, s := range seeds {
 		f.Add(s, "value")
@@ -65,13 +80,11 @@ func FuzzCheck(f *testing.F) {
 		// map directly with synthetic CheckerContexts. This still covers
 		// the full evaluation surface that struct-tag-driven validation
 		// would reach.
-		rules := parseRules(rule)
+		rules, err := parseRules(rule)
+		if err != nil {
+			return
+		}
 		for _, r := range rules {
-			fn, ok := Checkers[r.checker]
-			if !ok {
-				continue
-			}
-
 			// Synthesize a real struct so equal/cross-field checkers don't
 			// blow up — they need a valid StructValue to look siblings up.
 			holder := struct {
@@ -87,7 +100,7 @@ func FuzzCheck(f *testing.F) {
 							r.checker, r.params, value, rec)
 					}
 				}()
-				_ = fn(CheckerContext{
+				_ = checkRule(CheckerContext{
 					FieldName:   "Field",
 					FieldLabel:  "Field",
 					FieldValue:  value,
@@ -100,12 +113,19 @@ func FuzzCheck(f *testing.F) {
 	})
 }
 
-// FuzzParseRules just hammers the rule parser. Combined with FuzzCheck it
-// gives full coverage of the rule grammar.
+// FuzzParseRules hammers the rule parser. Malformed tags must yield a
+// positioned *ParseError, and well-formed ones must survive a round trip
+// through formatRules. Combined with FuzzCheck it gives full coverage of
+// the rule grammar.
 func FuzzParseRules(f *testing.F) {
 	for _, s := range []string{
 		"", ";", "::", "a:b:c", "a:b,c,d,e", "x;y;z", "a:1,2;b:3",
 		"required", "min:0;max:100", "list:a,b,c",
+		"regex:^a;b,c$", "list:'a;b',c", "list:'unterminated",
+		" required ; min : 1 , 2 ", `list:a\,b,c\;d,\'e`, `list:'it\'s','a\\b',''`,
+		"min:", ":foo", "list:'a'b", "list:a,,b", "re quired",
+		"email|mobile", "not:list:a,b", "(a;b)|c", "not:(a|b);c", "a:(1)", "(a:x\\)|b)",
+		"(a", "a)", "()", "a|", "not:", "(a)b", "not :0", "nothing:1",
 	} {
 		f.Add(s)
 	}
@@ -115,6 +135,35 @@ func FuzzParseRules(f *testing.F) {
 				t.Fatalf("parseRules panicked on %q: %v", raw, rec)
 			}
 		}()
-		_ = parseRules(raw)
+
+		rules, err := parseRules(raw)
+		if err != nil {
+			parseErr, ok := err.(*ParseError)
+			if !ok {
+				t.Fatalf("parseRules(%q) returned %T, want *ParseError", raw, err)
+			}
+			if parseErr.Offset < 0 || parseErr.Offset > len(raw) {
+				t.Fatalf("parseRules(%q) error offset %d out of range", raw, parseErr.Offset)
+			}
+			return
+		}
+
+		formatted := formatRules(rules)
+		again, err := parseRules(formatted)
+		if err != nil {
+			t.Fatalf("parseRules(%q) = %v, reformatted as %q: %v", raw, rules, formatted, err)
+		}
+		if !reflect.DeepEqual(rules, again) {
+			t.Fatalf("round trip of %q through %q changed the rules", raw, formatted)
+		}
 	})
 }
+
+// formatRules formats rules as a tag in their canonical text.
+func formatRules(rules []*rule) string {
+	texts := make([]string, len(rules))
+	for i, r := range rules {
+		texts[i] = r.String()
+	}
+	return strings.Join(texts, ";")
+}
@@ -1,6 +1,7 @@
 package govalid
 
 import (
+	"context"
 	"reflect"
 
 	"golang.org/x/text/language"
@@ -8,8 +9,29 @@ import (
 
 // Check checks the struct value.
 func Check(v interface{}, lang ...language.Tag) (errs []*ErrContext, ok bool) {
+	opts := make([]Option, 0, 1)
+	if len(lang) > 0 {
+		opts = append(opts, WithLanguage(lang[0]))
+	}
+
+	// The background context is never cancelled, so check can't fail.
+	errs, _ = check(context.Background(), v, newCheckOptions(opts...))
+	return errs, len(errs) == 0
+}
+
+// CheckContext checks the struct value like Check, passing ctx to the
+// checkers through CheckerContext.Context and to a `Validate(ctx) error`
+// method. If ctx is cancelled, checking stops early and the errors found so
+// far are returned together with ctx.Err().
+func CheckContext(ctx context.Context, v interface{}, opts ...Option) ([]*ErrContext, error) {
+	return check(ctx, v, newCheckOptions(opts...))
+}
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+func check(ctx context.Context, v interface{}, opts *checkOptions) (errs []*ErrContext, err error) {
 	if v == nil {
-		return nil, true
+		return nil, nil
 	}
 
 	structType := reflect.TypeOf(v)
@@ -20,12 +42,16 @@ func Check(v interface{}, lang ...language.Tag) (errs []*ErrContext, ok bool) {
 	// and value receivers are discoverable when callers pass a value.
 	validateMethod := structValue.MethodByName("Validate")
 
+	// pointers are the pointers followed to reach the checked value, so
+	// that a pointer back to it isn't walked again.
+	var pointers []reflect.Value
 	if structType.Kind() == reflect.Ptr {
 		// Guard against nil pointer dereference. Without this, the call to
 		// reflect.Value.Field below would panic on a typed nil pointer.
 		if structValue.IsNil() {
-			return nil, true
+			return nil, nil
 		}
+		pointers = []reflect.Value{structValue}
 		structType = structType.Elem()
 		structValue = structValue.Elem()
 	}
@@ -35,65 +61,224 @@ func Check(v interface{}, lang ...language.Tag) (errs []*ErrContext, ok bool) {
 	switch structType.Kind() {
 	case reflect.Struct, reflect.Slice:
 	default:
-		return nil, true
+		return nil, nil
 	}
 
-	templateLanguage := defaultTemplateLanguage
-	if len(lang) > 0 {
-		templateLanguage = lang[0]
-	}
+	templateLanguage := opts.language
+
+	// The hooks run before the fields are read, so that they see what
+	// BeforeValidate changed.
+	hooks := callBeforeValidate(structValue, "", nil, pointers, nil)
+	// The AfterValidate hooks see the errors found so far even when the
+	// check stops early.
+	defer func() {
+		errs = callAfterValidate(hooks, errs)
+	}()
 
-	structFields := parseStruct(structType, structValue, templateLanguage)
+	rootNode := &structNode{value: structValue, index: -1, pointers: pointers}
+	structFields := parseStruct(structType, structValue, opts, rootNode)
 
 	for _, field := range structFields {
 		field := field
 
-		fieldErrorMessage := field.errorMessage
+		if field.validations != nil {
+			sl := &structLevel{ctx: ctx, node: field.node, root: structValue, opts: opts}
+			for _, validate := range field.validations {
+				validate(sl)
+			}
+			errs = append(errs, sl.errs...)
+			continue
+		}
 
-		for _, rule := range field.rules {
-			rule := rule
+		fieldErrorMessage := field.errorMessage
 
-			checkerName := rule.checker
-			checkerContext := CheckerContext{
-				StructValue:      structValue,
+		// A malformed rule tag is reported instead of its rules.
+		if field.rulesErr != nil {
+			errs = append(errs, MakeRuleSyntaxError(CheckerContext{
 				FieldName:        field.name,
-				FieldType:        field.typ,
+				FieldPath:        field.path,
 				FieldLabel:       field.label,
 				FieldValue:       field.value,
 				TemplateLanguage: templateLanguage,
-				Rule:             rule,
+			}, field.rulesErr))
+			continue
+		}
+
+		checkerContext := CheckerContext{
+			Context:          ctx,
+			StructValue:      field.node.value,
+			Parent:           field.node.parentValue(),
+			Root:             structValue,
+			FieldName:        field.name,
+			FieldPath:        field.path,
+			Index:            field.node.index,
+			StrictEmpty:      opts.strictEmpty,
+			FieldType:        field.typ,
+			FieldLabel:       field.label,
+			FieldValue:       field.value,
+			TemplateLanguage: templateLanguage,
+
+			node: field.node,
+		}
+
+		// done is set once omitempty or the field's error message ends the
+		// checks of the field.
+		done := false
+		for _, rule := range field.rules {
+			rule := rule
+
+			// Stop as soon as the caller gives up, e.g. when a checker
+			// querying a database hits the request deadline.
+			if err := ctx.Err(); err != nil {
+				return errs, err
 			}
 
-			checker, ok := Checkers[checkerName]
-			if !ok {
-				// Checker not found.
-				errs = append(errs, MakeCheckerNotFoundError(checkerContext))
+			checkerName := rule.checker
+			// omitempty skips the field's remaining rules when it's empty.
+			if checkerName == OmitEmpty {
+				if isEmpty(field.value, field.typ) {
+					done = true
+					break
+				}
 				continue
 			}
 
-			if err := checker(checkerContext); err != nil {
+			checkerContext.Rule = rule
+			if err := checkRule(checkerContext); err != nil {
 				// If the field's error message is not empty, use it.
 				if fieldErrorMessage != "" {
 					errs = append(errs, MakeUserDefinedError(fieldErrorMessage))
+					done = true
 					break
 				}
 
 				errs = append(errs, err)
 			}
 		}
+
+		// A field whose type validates itself does so after its rules.
+		if field.validator != nil && !done {
+			if err := ctx.Err(); err != nil {
+				return errs, err
+			}
+
+			checkerContext.Rule = invalidRule
+			if err := field.validator.ValidateField(checkerContext); err != nil {
+				if fieldErrorMessage != "" {
+					err = MakeUserDefinedError(fieldErrorMessage)
+				}
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return errs, err
 	}
 
-	if validateMethod.IsValid() &&
-		validateMethod.Type().NumIn() == 0 &&
-		validateMethod.Type().NumOut() == 1 &&
-		validateMethod.Type().Out(0).Kind() == reflect.Interface {
+	if validateErr := callValidate(ctx, validateMethod); validateErr != nil {
+		errs = append(errs, MakeUserDefinedError(validateErr.Error()))
+	}
+
+	return errs, ctx.Err()
+}
+
+// checkRule checks the field against c.Rule, which may be composite.
+func checkRule(c CheckerContext) *ErrContext {
+	r := c.Rule
+	switch r.op {
+	case ruleAll, ruleAlias:
+		for _, sub := range r.rules {
+			if sub.checker == OmitEmpty {
+				if isEmpty(c.FieldValue, c.FieldType) {
+					return nil
+				}
+				continue
+			}
+			c.Rule = sub
+			err := checkRule(c)
+			if err == nil {
+				continue
+			}
+			// An alias with a message key reports its own error.
+			if r.message != "" && !err.internal {
+				c.Rule = r
+				err = NewErrorContext(c)
+				err.SetTemplate(r.message)
+			}
+			return err
+		}
+		return nil
 
-		validateResult := validateMethod.Call(nil)[0].Interface()
-		validateErr, ok := validateResult.(error)
-		if ok && validateErr != nil {
-			errs = append(errs, MakeUserDefinedError(validateErr.Error()))
+	case ruleAny:
+		errs := make([]*ErrContext, 0, len(r.rules))
+		for _, sub := range r.rules {
+			c.Rule = sub
+			err := checkRule(c)
+			if err == nil {
+				return nil
+			}
+			errs = append(errs, err)
 		}
+		// A broken alternative is reported as such rather than as a
+		// mismatch.
+		for _, err := range errs {
+			if err.internal {
+				return err
+			}
+		}
+		c.Rule = r
+		return makeAnyOfError(c, errs)
+
+	case ruleNot:
+		// Like most checkers, negation accepts empty fields.
+		if isEmpty(c.FieldValue, c.FieldType) {
+			return nil
+		}
+		c.Rule = r.rules[0]
+		err := checkRule(c)
+		if err != nil {
+			if err.internal {
+				return err
+			}
+			return nil
+		}
+		c.Rule = r
+		return makeNotError(c)
 	}
 
-	return errs, len(errs) == 0
+	checker, ok := Checkers[r.checker]
+	if !ok {
+		return MakeCheckerNotFoundError(c)
+	}
+	return checker(c)
+}
+
+// callValidate calls the given Validate method if it has one of the
+// signatures `Validate() error` or `Validate(context.Context) error`.
+func callValidate(ctx context.Context, validateMethod reflect.Value) error {
+	if !validateMethod.IsValid() {
+		return nil
+	}
+
+	methodType := validateMethod.Type()
+	if methodType.NumOut() != 1 || methodType.Out(0).Kind() != reflect.Interface {
+		return nil
+	}
+
+	var in []reflect.Value
+	switch {
+	case methodType.NumIn() == 0:
+	case methodType.NumIn() == 1 && methodType.In(0) == contextType:
+		in = []reflect.Value{reflect.ValueOf(&ctx).Elem()}
+	default:
+		return nil
+	}
+
+	validateResult := validateMethod.Call(in)[0].Interface()
+	validateErr, ok := validateResult.(error)
+	if !ok {
+		return nil
+	}
+	return validateErr
 }
@@ -0,0 +1,149 @@
+package govalid
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// BeforeValidator is implemented by structs that prepare themselves before
+// they are checked, e.g. by normalizing phone numbers or deriving fields.
+// BeforeValidate is called on the checked value and every nested struct and
+// slice element, parents before their fields, before any rule is checked.
+// A struct can only change itself if it is addressable, i.e. if Check is
+// given a pointer.
+type BeforeValidator interface {
+	BeforeValidate()
+}
+
+// AfterValidator is implemented by structs that post-process their errors,
+// e.g. to drop the errors of fields hidden by the UI. AfterValidate is
+// called after all rules and Validate are checked, or with the errors
+// found so far when a cancelled context stops the check early. It is
+// called on the checked value and every nested struct and slice element,
+// in the reverse order of BeforeValidate, so that fields come before their
+// parents. It is given the errors of the fields under the struct, or all
+// errors for the checked value, and returns the errors to keep in their
+// place.
+type AfterValidator interface {
+	AfterValidate(errs []*ErrContext) []*ErrContext
+}
+
+// afterHook is an AfterValidator found while calling the BeforeValidate
+// hooks, with the path of its struct.
+type afterHook struct {
+	path  string
+	after AfterValidator
+}
+
+// callBeforeValidate calls the BeforeValidate hooks of value and the
+// structs under it, as parseStruct walks them, and returns their
+// AfterValidate hooks, appended to hooks in the same order. outer is the
+// struct embedding value, as given to the hooks, or nil: the hooks of an
+// embedded struct that are promoted to the struct embedding it, or
+// overridden by it, are only called through that struct. pointers are the
+// pointers followed to reach value, as in nestedStruct.
+func callBeforeValidate(value reflect.Value, path string, outer interface{}, pointers []reflect.Value, hooks []afterHook) []afterHook {
+	if value.Kind() == reflect.Slice {
+		if !isNestedSlice(value.Type()) {
+			return hooks
+		}
+		for i := 0; i < value.Len(); i++ {
+			hooks = callBeforeValidate(value.Index(i), path+"["+strconv.Itoa(i)+"]", nil, pointers, hooks)
+		}
+		return hooks
+	}
+	value, pointers, ok := nestedStruct(value, pointers)
+	if !ok {
+		return hooks
+	}
+
+	self := structHook(value)
+	if hook, ok := self.(BeforeValidator); ok {
+		if _, promoted := outer.(BeforeValidator); !promoted {
+			hook.BeforeValidate()
+		}
+	}
+	if hook, ok := self.(AfterValidator); ok {
+		if _, promoted := outer.(AfterValidator); !promoted {
+			hooks = append(hooks, afterHook{path: path, after: hook})
+		}
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		var embedding interface{}
+		if field.Anonymous {
+			embedding = self
+		}
+		if isNestedSlice(field.Type) {
+			hooks = callBeforeValidate(value.Field(i), joinPath(path, field.Name), nil, pointers, hooks)
+		}
+		if _, ok := nestedStructType(field.Type); ok {
+			hooks = callBeforeValidate(value.Field(i), joinPath(path, field.Name), embedding, pointers, hooks)
+		}
+	}
+	return hooks
+}
+
+// structHook returns the struct value as an interface value for the hook
+// methods, through a pointer if it is addressable, or nil if it can't be
+// read.
+func structHook(value reflect.Value) interface{} {
+	if !value.CanInterface() {
+		return nil
+	}
+	if value.CanAddr() {
+		return value.Addr().Interface()
+	}
+	return value.Interface()
+}
+
+// callAfterValidate calls the AfterValidate hooks in reverse order, each
+// with the errors under its struct.
+func callAfterValidate(hooks []afterHook, errs []*ErrContext) []*ErrContext {
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hook := hooks[i]
+		if hook.path == "" {
+			errs = hook.after.AfterValidate(errs)
+			continue
+		}
+
+		// The struct's errors are replaced where the first of them was.
+		at := -1
+		own := make([]*ErrContext, 0)
+		rest := make([]*ErrContext, 0, len(errs))
+		for _, err := range errs {
+			if err != nil && isUnderPath(err.FieldPath, hook.path) {
+				if at < 0 {
+					at = len(rest)
+				}
+				own = append(own, err)
+				continue
+			}
+			rest = append(rest, err)
+		}
+		if at < 0 {
+			at = len(rest)
+		}
+		kept := hook.after.AfterValidate(own)
+		errs = make([]*ErrContext, 0, len(rest)+len(kept))
+		errs = append(errs, rest[:at]...)
+		errs = append(errs, kept...)
+		errs = append(errs, rest[at:]...)
+	}
+	return errs
+}
+
+// isUnderPath reports whether the field path is that of the struct at path
+// or of a field under it.
+func isUnderPath(fieldPath, path string) bool {
+	if !strings.HasPrefix(fieldPath, path) {
+		return false
+	}
+	rest := fieldPath[len(path):]
+	return rest == "" || rest[0] == '.' || rest[0] == '['
+}
@@ -0,0 +1,217 @@
+package govalid
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// hookCalls records the hook calls of a test, in order.
+var hookCalls []string
+
+type hookedContact struct {
+	Phone  string `valid:"required;mobile" label:"电话"`
+	Hidden bool
+}
+
+func (c *hookedContact) BeforeValidate() {
+	hookCalls = append(hookCalls, "before contact")
+	c.Phone = strings.ReplaceAll(c.Phone, " ", "")
+}
+
+func (c *hookedContact) AfterValidate(errs []*ErrContext) []*ErrContext {
+	hookCalls = append(hookCalls, "after contact")
+	if c.Hidden {
+		return nil
+	}
+	return errs
+}
+
+type hookedForm struct {
+	FirstName string
+	LastName  string
+	FullName  string `valid:"required;maxlen:8" label:"姓名"`
+	Contacts  []hookedContact
+	Note      string `valid:"required" label:"备注"`
+}
+
+func (f *hookedForm) BeforeValidate() {
+	hookCalls = append(hookCalls, "before form")
+	f.FullName = f.FirstName + f.LastName
+}
+
+func (f *hookedForm) Validate() error {
+	hookCalls = append(hookCalls, "validate")
+	return nil
+}
+
+func (f *hookedForm) AfterValidate(errs []*ErrContext) []*ErrContext {
+	hookCalls = append(hookCalls, "after form")
+	return errs[1:]
+}
+
+func Test_Hooks(t *testing.T) {
+	hookCalls = nil
+	t.Cleanup(func() { hookCalls = nil })
+
+	f := &hookedForm{
+		FirstName: "张",
+		LastName:  "三",
+		Contacts:  []hookedContact{{Phone: "133 8888 6666"}, {Phone: "nope"}, {Phone: "", Hidden: true}},
+	}
+	errs, _ := Check(f)
+
+	// Parents are prepared before their fields, and see their errors after.
+	assert.Equal(t, []string{
+		"before form", "before contact", "before contact", "before contact",
+		"validate",
+		"after contact", "after contact", "after contact", "after form",
+	}, hookCalls)
+	assert.Equal(t, "张三", f.FullName)
+	assert.Equal(t, "13388886666", f.Contacts[0].Phone)
+
+	// The hidden contact's error is dropped, then the form drops the first
+	// of the rest.
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "Note", errs[0].FieldPath)
+}
+
+func Test_Hooks_ErrorPlacement(t *testing.T) {
+	hookCalls = nil
+	t.Cleanup(func() { hookCalls = nil })
+
+	type form struct {
+		Name    string `valid:"required" label:"名字"`
+		Contact hookedContact
+		Email   string `valid:"required" label:"邮箱"`
+	}
+
+	errs, _ := Check(&form{Contact: hookedContact{Phone: "nope"}})
+	assert.Equal(t, 3, len(errs))
+	assert.Equal(t, []string{"Name", "Contact.Phone", "Email"}, []string{errs[0].FieldPath, errs[1].FieldPath, errs[2].FieldPath})
+
+	errs, _ = Check(&form{Contact: hookedContact{Hidden: true}})
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "Email", errs[1].FieldPath)
+
+	// Without a pointer the hooks can't change the struct, so those with
+	// pointer receivers aren't called.
+	hookCalls = nil
+	_, _ = Check(form{})
+	assert.Empty(t, hookCalls)
+}
+
+// HookedProfile is exported so that its hooks can be reached when it's
+// embedded.
+type HookedProfile struct {
+	Phone  string `valid:"required;mobile" label:"电话"`
+	Hidden bool
+}
+
+func (p *HookedProfile) BeforeValidate() {
+	hookCalls = append(hookCalls, "before profile")
+	p.Phone = strings.ReplaceAll(p.Phone, " ", "")
+}
+
+func (p *HookedProfile) AfterValidate(errs []*ErrContext) []*ErrContext {
+	hookCalls = append(hookCalls, "after profile")
+	if p.Hidden {
+		return nil
+	}
+	return errs
+}
+
+func Test_Hooks_Embedded(t *testing.T) {
+	hookCalls = nil
+	t.Cleanup(func() { hookCalls = nil })
+
+	type form struct {
+		HookedProfile
+		Name string `valid:"required" label:"名字"`
+	}
+
+	// The embedded struct's hooks are promoted to the form, and called
+	// once through it, with all of its errors.
+	f := &form{HookedProfile: HookedProfile{Phone: "133 8888 6666", Hidden: true}}
+	errs, _ := Check(f)
+	assert.Equal(t, []string{"before profile", "after profile"}, hookCalls)
+	assert.Equal(t, "13388886666", f.Phone)
+	assert.Empty(t, errs)
+
+	// A struct overriding a hook of the struct it embeds calls it itself
+	// if it wants to. The other hook is still promoted.
+	hookCalls = nil
+	_, _ = Check(&hookedOverride{})
+	assert.Equal(t, []string{"before override", "after profile"}, hookCalls)
+}
+
+type hookedOverride struct {
+	HookedProfile
+}
+
+func (o *hookedOverride) BeforeValidate() {
+	hookCalls = append(hookCalls, "before override")
+}
+
+func Test_Hooks_Pointers(t *testing.T) {
+	hookCalls = nil
+	t.Cleanup(func() { hookCalls = nil })
+
+	type form struct {
+		Primary  *hookedContact
+		Contacts []*hookedContact
+	}
+
+	// Structs behind pointers can be changed even if the form can't.
+	f := form{Primary: &hookedContact{Phone: "133 8888 6666"}, Contacts: []*hookedContact{nil, {Phone: "nope"}}}
+	errs, _ := Check(f)
+	assert.Equal(t, []string{"before contact", "before contact", "after contact", "after contact"}, hookCalls)
+	assert.Equal(t, "13388886666", f.Primary.Phone)
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "Contacts[1].Phone", errs[0].FieldPath)
+}
+
+func Test_isUnderPath(t *testing.T) {
+	assert.True(t, isUnderPath("Items[1].Name", "Items[1]"))
+	assert.True(t, isUnderPath("Items[1]", "Items[1]"))
+	assert.True(t, isUnderPath("Items[1][2]", "Items[1]"))
+	assert.False(t, isUnderPath("Items[10].Name", "Items[1]"))
+	assert.False(t, isUnderPath("ItemsCount", "Items"))
+}
+
+// hookForm drops every error. Its first rule cancels the check.
+type hookForm struct {
+	A string `valid:"cancelHook"`
+	B string `valid:"cancelHook"`
+}
+
+func (f *hookForm) BeforeValidate() {
+	hookCalls = append(hookCalls, "before form")
+}
+
+func (f *hookForm) AfterValidate(errs []*ErrContext) []*ErrContext {
+	hookCalls = append(hookCalls, "after form")
+	return nil
+}
+
+func Test_Hooks_Cancelled(t *testing.T) {
+	hookCalls = nil
+	t.Cleanup(func() { hookCalls = nil })
+	defer delete(Checkers, "cancelHook")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	Checkers["cancelHook"] = func(c CheckerContext) *ErrContext {
+		cancel()
+		return NewErrorContext(c)
+	}
+
+	// The hooks are paired, and filter the errors found before the check
+	// stopped.
+	errs, err := CheckContext(ctx, &hookForm{})
+	assert.Equal(t, context.Canceled, err)
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{"before form", "after form"}, hookCalls)
+}
@@ -136,7 +136,7 @@ func Test_Integration_LanguageFallback(t *testing.T) {
 	})
 
 	t.Run("unknown locale falls back to chinese template", func(t *testing.T) {
-		errs, _ := Check(v, language.Korean)
+		errs, _ := Check(v, language.Vietnamese)
 		// The template falls back to chinese, but the label still picks
 		// the unqualified "label" tag because there's no label-ko.
 		assert.Equal(t, "用户名不能为空", errs[0].Error())
@@ -0,0 +1,75 @@
+package govalid
+
+import (
+	"math/big"
+	"net"
+	"net/url"
+	"reflect"
+	"time"
+)
+
+// leafTypes is the registry of struct-like types that are validated as
+// values instead of being walked field by field.
+var leafTypes = map[reflect.Type]struct{}{
+	reflect.TypeOf(time.Time{}): {},
+	reflect.TypeOf(big.Int{}):   {},
+	reflect.TypeOf(big.Float{}): {},
+	reflect.TypeOf(big.Rat{}):   {},
+	reflect.TypeOf(url.URL{}):   {},
+	reflect.TypeOf(net.IP{}):    {},
+	reflect.TypeOf([16]byte{}):  {},
+	reflect.TypeOf(net.IPNet{}): {},
+}
+
+// RegisterLeafType registers the type of v, or the type v points to, as an
+// opaque leaf: fields of that type are validated as values by their own
+// rules, and the fields of the type itself are never descended into.
+//
+// Example:
+//
+//	govalid.RegisterLeafType(decimal.Decimal{})
+func RegisterLeafType(v interface{}) {
+	typ := reflect.TypeOf(v)
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	leafTypes[typ] = struct{}{}
+}
+
+// isLeafType reports whether typ is a registered leaf type. Any 16 byte
+// array, such as a UUID type, is a leaf as well.
+func isLeafType(typ reflect.Type) bool {
+	if _, ok := leafTypes[typ]; ok {
+		return true
+	}
+	return typ.Kind() == reflect.Array && typ.Len() == 16 && typ.Elem().Kind() == reflect.Uint8
+}
+
+type zeroer interface {
+	IsZero() bool
+}
+
+type signer interface {
+	Sign() int
+}
+
+// isZeroLeaf reports whether a leaf value is zero, using its IsZero method
+// (time.Time) or Sign method (big.Int, big.Float, big.Rat) when it has
+// one. Methods with pointer receivers are called on a copy.
+func isZeroLeaf(value interface{}) bool {
+	rv := reflect.ValueOf(value)
+	ptr := reflect.New(rv.Type())
+	ptr.Elem().Set(rv)
+
+	switch v := ptr.Interface().(type) {
+	case zeroer:
+		return v.IsZero()
+	case signer:
+		return v.Sign() == 0
+	}
+
+	if rv.Kind() == reflect.Slice {
+		return rv.Len() == 0
+	}
+	return rv.IsZero()
+}
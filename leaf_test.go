@@ -0,0 +1,80 @@
+package govalid
+
+import (
+	"math/big"
+	"net"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type uuid [16]byte
+
+func Test_LeafTypes_Required(t *testing.T) {
+	type form struct {
+		At    time.Time `valid:"required" label:"时间"`
+		Big   big.Int   `valid:"required" label:"大数"`
+		Rat   big.Rat   `valid:"required" label:"分数"`
+		Link  url.URL   `valid:"required" label:"链接"`
+		IP    net.IP    `valid:"required" label:"IP"`
+		ID    uuid      `valid:"required" label:"编号"`
+		Times []time.Time
+	}
+
+	t.Run("zero values are empty", func(t *testing.T) {
+		errs, ok := Check(form{})
+		assert.False(t, ok)
+		assert.Equal(t, 6, len(errs))
+		assert.Equal(t, "时间不能为空", errs[0].Error())
+		assert.Equal(t, "大数不能为空", errs[1].Error())
+		assert.Equal(t, "编号不能为空", errs[5].Error())
+		for _, err := range errs {
+			assert.NotEmpty(t, err.FieldPath)
+			assert.NotContains(t, err.FieldPath, ".", "leaf types must not be descended into")
+		}
+	})
+
+	t.Run("set values pass", func(t *testing.T) {
+		v := form{
+			At:   time.Now(),
+			Link: url.URL{Scheme: "https", Host: "github.red"},
+			IP:   net.ParseIP("127.0.0.1"),
+			ID:   uuid{1},
+		}
+		v.Big.SetInt64(1)
+		v.Rat.SetFrac64(1, 3)
+		_, ok := Check(v)
+		assert.True(t, ok)
+	})
+
+	t.Run("zero time in another location is still zero", func(t *testing.T) {
+		v := struct {
+			At time.Time `valid:"required"`
+		}{At: time.Time{}.In(time.FixedZone("CST", 8*3600))}
+		_, ok := Check(v)
+		assert.False(t, ok)
+	})
+}
+
+type opaqueMoney struct {
+	Cents int64 `valid:"min:0"`
+}
+
+func Test_RegisterLeafType(t *testing.T) {
+	v := struct {
+		Price opaqueMoney
+	}{Price: opaqueMoney{Cents: -1}}
+
+	// Walked like a form by default.
+	_, ok := Check(v)
+	assert.False(t, ok)
+
+	RegisterLeafType(&opaqueMoney{})
+	defer delete(leafTypes, reflect.TypeOf(opaqueMoney{}))
+
+	_, ok = Check(v)
+	assert.True(t, ok)
+}
@@ -0,0 +1,50 @@
+package govalid
+
+import "golang.org/x/text/language"
+
+// Option configures a single CheckContext call.
+type Option func(*checkOptions)
+
+// checkOptions holds the settings of a single check.
+type checkOptions struct {
+	language           language.Tag
+	hierarchicalLabels bool
+	strictEmpty        bool
+	// fields are the rules built in code by field path, for FieldSet.
+	fields map[string]*FieldRules
+}
+
+// newCheckOptions returns the settings of a check, starting from the
+// package level defaults.
+func newCheckOptions(opts ...Option) *checkOptions {
+	o := &checkOptions{
+		language:           defaultTemplateLanguage,
+		hierarchicalLabels: HierarchicalLabels,
+		strictEmpty:        StrictEmpty,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithLanguage sets the language of the error messages and labels.
+func WithLanguage(lang language.Tag) Option {
+	return func(o *checkOptions) {
+		o.language = lang
+	}
+}
+
+// WithHierarchicalLabels overrides HierarchicalLabels for a single check.
+func WithHierarchicalLabels(enabled bool) Option {
+	return func(o *checkOptions) {
+		o.hierarchicalLabels = enabled
+	}
+}
+
+// WithStrictEmpty overrides StrictEmpty for a single check.
+func WithStrictEmpty(enabled bool) Option {
+	return func(o *checkOptions) {
+		o.strictEmpty = enabled
+	}
+}
@@ -2,6 +2,7 @@ package govalid
 
 import (
 	"reflect"
+	"strconv"
 	"strings"
 
 	"golang.org/x/text/language"
@@ -14,34 +15,262 @@ var (
 	LabelField = "label"
 	// MessageField is the error message tag's name.
 	MessageField = "msg"
+
+	// HierarchicalLabels makes the labels of nested struct fields and slice
+	// elements include the labels of their parents, e.g. "收货地址·城市" or
+	// "第3个商品的名称". The join and index formats are taken from the
+	// "_labelNested", "_labelElement" and "_labelItem" templates of the
+	// current language.
+	HierarchicalLabels = false
+)
+
+var (
+	labelParentPlaceholder = "{parent}"
+	labelChildPlaceholder  = "{child}"
+	labelIndexPlaceholder  = "{index}"
 )
 
 // structField is one of the struct field.
 type structField struct {
 	name  string
+	path  string
 	typ   reflect.Type
 	value interface{}
+	node  *structNode
 
 	label        string
 	errorMessage string
 
 	rawRules string
 	rules    []*rule
+	rulesErr error
+	// validator is the field's value if its type implements
+	// FieldValidator.
+	validator FieldValidator
+
+	// validations are set instead of the rest for the registered struct
+	// validations of the struct at node.
+	validations []func(StructLevel)
+}
+
+// structNode is a struct reached while walking the checked value.
+type structNode struct {
+	value  reflect.Value
+	parent *structNode
+	// path is the path of the struct from the checked value,
+	// e.g. "Items[2]". It is empty for the checked value itself.
+	path string
+	// index is the index of the struct in its enclosing slice, or -1.
+	index int
+	scope labelScope
+	// embedded is set for an embedded struct, whose fields are promoted to
+	// its parent.
+	embedded bool
+	// pointers are the pointers followed to reach the struct.
+	pointers []reflect.Value
+}
+
+// child returns the node of a struct reached through the given field name
+// or slice index of n.
+func (n *structNode) child(value reflect.Value, name string, index int, scope labelScope) *structNode {
+	path := joinPath(n.path, name)
+	if index >= 0 {
+		path += "[" + strconv.Itoa(index) + "]"
+	}
+	return &structNode{
+		value:    value,
+		parent:   n,
+		path:     path,
+		index:    index,
+		scope:    scope,
+		pointers: n.pointers,
+	}
+}
+
+// refScope returns the node of the struct that field references from the
+// fields of n resolve against: n, or the struct embedding it.
+func (n *structNode) refScope() *structNode {
+	for n.embedded && n.parent != nil {
+		n = n.parent
+	}
+	return n
+}
+
+// parentValue returns the value of the enclosing struct, or the zero
+// reflect.Value if n is the checked value itself.
+func (n *structNode) parentValue() reflect.Value {
+	if n.parent == nil {
+		return reflect.Value{}
+	}
+	return n.parent.value
+}
+
+// nestedStructType returns the struct type that a field or slice element
+// of type typ holds, directly or through a pointer, and whether its fields
+// are walked: leaf types such as time.Time are validated as values instead.
+func nestedStructType(typ reflect.Type) (reflect.Type, bool) {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ, typ.Kind() == reflect.Struct && !isLeafType(typ)
+}
+
+// isNestedSlice reports whether typ is a slice of structs, or of pointers
+// to them, whose fields are walked.
+func isNestedSlice(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Slice {
+		return false
+	}
+	_, ok := nestedStructType(typ.Elem())
+	return ok
+}
+
+// nestedStruct returns the struct that value holds, directly or through a
+// non-nil pointer, if its fields are walked. pointers are the pointers
+// followed to reach value, returned with the one to the struct appended; a
+// pointer among them would be a cycle, and is not followed again.
+func nestedStruct(value reflect.Value, pointers []reflect.Value) (reflect.Value, []reflect.Value, bool) {
+	if _, ok := nestedStructType(value.Type()); !ok {
+		return reflect.Value{}, nil, false
+	}
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return reflect.Value{}, nil, false
+		}
+		for _, pointer := range pointers {
+			if pointer.Pointer() == value.Pointer() && pointer.Type() == value.Type() {
+				return reflect.Value{}, nil, false
+			}
+		}
+		pointers = append(pointers[:len(pointers):len(pointers)], value)
+		value = value.Elem()
+	}
+	return value, pointers, true
+}
+
+// joinPath joins a field name to a struct path.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// labelScope composes a field label with the labels of its enclosing
+// structs and slice elements. A nil scope leaves labels untouched.
+type labelScope func(label string) string
+
+func (s labelScope) label(label string) string {
+	if s == nil {
+		return label
+	}
+	return s(label)
+}
+
+// nested returns the scope of the fields of a struct-typed field labelled
+// parent.
+func (s labelScope) nested(parent string, languageTag language.Tag) labelScope {
+	template := labelTemplate("_labelNested", languageTag)
+	return func(child string) string {
+		return s.label(strings.NewReplacer(
+			labelParentPlaceholder, parent,
+			labelChildPlaceholder, child,
+		).Replace(template))
+	}
+}
+
+// element returns the scope of the fields of the index-th (zero-based)
+// element of a slice field labelled parent.
+func (s labelScope) element(parent string, index int, languageTag language.Tag) labelScope {
+	template := labelTemplate("_labelElement", languageTag)
+	return func(child string) string {
+		return s.label(strings.NewReplacer(
+			labelParentPlaceholder, parent,
+			labelIndexPlaceholder, strconv.Itoa(index+1),
+			labelChildPlaceholder, child,
+		).Replace(template))
+	}
 }
 
-// parseStruct parses the given struct field.
-func parseStruct(structType reflect.Type, structValue reflect.Value, languageTag language.Tag) []*structField {
+// item returns the scope of the fields of the index-th (zero-based)
+// element of the slice passed to Check, which has no label of its own.
+func (s labelScope) item(index int, languageTag language.Tag) labelScope {
+	template := labelTemplate("_labelItem", languageTag)
+	return func(child string) string {
+		return s.label(strings.NewReplacer(
+			labelIndexPlaceholder, strconv.Itoa(index+1),
+			labelChildPlaceholder, child,
+		).Replace(template))
+	}
+}
+
+// labelTemplate returns the label composition template of the given key.
+// Unlike error templates, it falls back to the default language's template
+// rather than the unknown error template.
+func labelTemplate(key string, languageTag language.Tag) string {
+	if template, ok := lookupTemplateSet(languageTag)[key]; ok {
+		return template
+	}
+	return errorTemplateSet[defaultTemplateLanguage][key]
+}
+
+// fieldLabel returns the label of the given field of the struct type owner
+// for the language. A label registered with RegisterRules takes precedence
+// over the label tags. The language specific label takes precedence over
+// the plain label, which in turn takes precedence over the field name.
+// e.g. `label:"Name" label-en:"Name" label-zh:"姓名"`
+func fieldLabel(owner reflect.Type, field reflect.StructField, languageTag language.Tag) string {
+	if label, ok := registeredLabel(owner, field.Name, languageTag); ok {
+		return label
+	}
+	if languageTag.String() != "" {
+		if labelValue, ok := field.Tag.Lookup(LabelField + "-" + languageTag.String()); ok {
+			return labelValue
+		}
+	}
+	if labelValue, ok := field.Tag.Lookup(LabelField); ok {
+		return labelValue
+	}
+	return field.Name
+}
+
+// parseStruct parses the given struct field. The node describes where
+// structValue sits in the checked value.
+func parseStruct(structType reflect.Type, structValue reflect.Value, opts *checkOptions, node *structNode) []*structField {
+	languageTag := opts.language
 	fields := make([]*structField, 0)
-	rulesSets := make(map[string][]*rule)
+	type ruleSet struct {
+		rules []*rule
+		err   error
+	}
+	rulesSets := make(map[string]ruleSet)
 
-	// Check if is a struct slice, and parse each struct.
+	// Check if is a struct slice, and parse each struct. The elements are
+	// siblings of the slice itself, so they share its parent.
 	if structType.Kind() == reflect.Slice {
 		for i := 0; i < structValue.Len(); i++ {
-			structFields := parseStruct(structType.Elem(), structValue.Index(i), languageTag)
+			element, pointers, ok := nestedStruct(structValue.Index(i), node.pointers)
+			if !ok {
+				continue
+			}
+			elementScope := node.scope
+			if opts.hierarchicalLabels {
+				elementScope = node.scope.item(i, languageTag)
+			}
+			elementNode := &structNode{
+				value:    element,
+				parent:   node.parent,
+				path:     node.path + "[" + strconv.Itoa(i) + "]",
+				index:    i,
+				scope:    elementScope,
+				pointers: pointers,
+			}
+			structFields := parseStruct(element.Type(), element, opts, elementNode)
 			fields = append(fields, structFields...)
 		}
 		return fields
 	}
+	scope := node.scope
 
 	for i := 0; i < structType.NumField(); i++ {
 		field := structType.Field(i)
@@ -61,16 +290,39 @@ func parseStruct(structType reflect.Type, structValue reflect.Value, languageTag
 			continue
 		}
 
-		// Check if the field is a struct slice.
-		if field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct {
+		// Check if the field is a slice of structs or of pointers to them.
+		// Slices of leaf types such as []time.Time are values, not lists of
+		// forms, and nil pointers have nothing to check.
+		if isNestedSlice(field.Type) {
 			for j := 0; j < structValue.Field(i).Len(); j++ {
-				fields = append(fields, parseStruct(field.Type.Elem(), structValue.Field(i).Index(j), languageTag)...)
+				element, pointers, ok := nestedStruct(structValue.Field(i).Index(j), node.pointers)
+				if !ok {
+					continue
+				}
+				elementScope := scope
+				if opts.hierarchicalLabels {
+					elementScope = scope.element(fieldLabel(structType, field, languageTag), j, languageTag)
+				}
+				elementNode := node.child(element, field.Name, j, elementScope)
+				elementNode.pointers = pointers
+				fields = append(fields, parseStruct(element.Type(), element, opts, elementNode)...)
 			}
 		}
 
-		// Check if the field is a struct.
-		if field.Type.Kind() == reflect.Struct {
-			fields = append(fields, parseStruct(field.Type, structValue.Field(i), languageTag)...)
+		// Check if the field is a struct or a non-nil pointer to one. Leaf
+		// types such as time.Time are validated as values by the field's
+		// own rules instead.
+		if nested, pointers, ok := nestedStruct(structValue.Field(i), node.pointers); ok {
+			// Embedded structs promote their fields, so they don't add a
+			// level to the label hierarchy.
+			nestedScope := scope
+			if opts.hierarchicalLabels && !field.Anonymous {
+				nestedScope = scope.nested(fieldLabel(structType, field, languageTag), languageTag)
+			}
+			nestedNode := node.child(nested, field.Name, -1, nestedScope)
+			nestedNode.embedded = field.Anonymous
+			nestedNode.pointers = pointers
+			fields = append(fields, parseStruct(nested.Type(), nested, opts, nestedNode)...)
 		}
 
 		// Anonymous unexported fields can't have their value extracted via
@@ -81,92 +333,81 @@ func parseStruct(structType reflect.Type, structValue reflect.Value, languageTag
 			continue
 		}
 
-		// Check if this field has a validator tag.
-		rawRules, ok := field.Tag.Lookup(RulesField)
-		if !ok {
+		// Check if this field has a validator tag or registered rules.
+		// Rules built in code are checked after them.
+		ruleTags := fieldRuleTags(structType, field)
+		built := opts.fields[joinPath(node.path, field.Name)]
+		validator, hasValidator := fieldValidatorOf(structValue.Field(i))
+		_, hasTypeRules := fieldTypeRules(field.Type)
+		if ruleTags == nil && built == nil && !hasValidator && !hasTypeRules {
 			continue
 		}
 
 		name := field.Name
 		// Check if this field has a customized label name.
-		label := name
-		labelField := LabelField
-		// We accept user specified language tag.
-		// e.g. `label:"Name" label-en:"Name" label-zh:"姓名"`
-		if languageTag.String() != "" {
-			labelField += "-" + languageTag.String()
-		}
-
-		if labelValue, ok := structType.Field(i).Tag.Lookup(labelField); ok {
-			label = labelValue
-		} else {
-			if labelValue, ok = structType.Field(i).Tag.Lookup(LabelField); ok {
-				label = labelValue
-			}
+		label := fieldLabel(structType, field, languageTag)
+		if builtLabel, ok := built.label(languageTag); ok {
+			label = builtLabel
 		}
+		label = scope.label(label)
 
 		var errorMessage string
 		if messageValue, ok := structType.Field(i).Tag.Lookup(MessageField); ok {
 			errorMessage = messageValue
 		}
+		if built != nil && built.message != "" {
+			errorMessage = built.message
+		}
 		typ := structValue.Field(i).Type()
 		value := structValue.Field(i).Interface()
 
 		// Parse validation rules.
 		// We store every field's rules in a map, so we can only parse the same rules once.
 		var rules []*rule
-		if rulesSet, ok := rulesSets[rawRules]; ok {
-			rules = rulesSet
-		} else {
-			rules = parseRules(rawRules)
-			rulesSets[rawRules] = rules
+		var rulesErr error
+		for _, rawRules := range ruleTags {
+			rulesSet, ok := rulesSets[rawRules]
+			if !ok {
+				rulesSet.rules, rulesSet.err = parseRules(rawRules)
+				rulesSets[rawRules] = rulesSet
+			}
+			if rulesSet.err != nil {
+				rulesErr = rulesSet.err
+				break
+			}
+			rules = append(rules[:len(rules):len(rules)], rulesSet.rules...)
+		}
+		if built != nil && rulesErr == nil {
+			rules, rulesErr = append(rules[:len(rules):len(rules)], built.rules...), built.err
+		}
+		if rulesErr == nil {
+			rules = withTypeRules(field.Type, rules)
 		}
 
 		fields = append(fields, &structField{
 			name:         name,
+			path:         joinPath(node.path, name),
 			typ:          typ,
 			value:        value,
+			node:         node,
 			label:        label,
 			errorMessage: errorMessage,
-			rawRules:     rawRules,
+			rawRules:     strings.Join(ruleTags, ";"),
 			rules:        rules,
+			rulesErr:     rulesErr,
+			validator:    validator,
 		})
 	}
 
-	return fields
-}
-
-// rule is a single validator rule context of a struct field.
-type rule struct {
-	checker string
-	params  []string
-}
-
-func parseRules(rawRules string) []*rule {
-	rules := make([]*rule, 0)
-
-	segments := strings.Split(rawRules, ";")
-	for _, segment := range segments {
-		if segment == "" {
-			continue
-		}
-
-		if strings.Contains(segment, ":") {
-			// key - value
-			kv := strings.SplitN(segment, ":", 2)
-			if kv[0] == "" {
-				continue
-			}
-			rules = append(rules, &rule{
-				checker: kv[0],
-				params:  strings.Split(kv[1], ","),
-			})
-		} else {
-			// value
-			rules = append(rules, &rule{
-				checker: segment,
-			})
-		}
+	// Struct validations run after the rules of the struct's fields.
+	if s, ok := registeredStructs[structType]; ok && len(s.validations) > 0 {
+		fields = append(fields, &structField{
+			path:        node.path,
+			typ:         structType,
+			node:        node,
+			validations: s.validations,
+		})
 	}
-	return rules
+
+	return fields
 }
@@ -36,12 +36,10 @@ func Test_parseRules_Extra(t *testing.T) {
 			},
 		},
 		{
-			name: "spaces preserved in checker name",
-			// We don't trim, so callers shouldn't add spaces. Ensure that's
-			// stable behavior, not silently stripping.
+			name: "spaces around checker name trimmed",
 			rule: "required ;min:0",
 			want: []*rule{
-				{checker: "required "},
+				{checker: "required"},
 				{checker: "min", params: []string{"0"}},
 			},
 		},
@@ -60,11 +58,6 @@ func Test_parseRules_Extra(t *testing.T) {
 				{checker: "url", params: []string{"http://example.com"}},
 			},
 		},
-		{
-			name: "value-only with empty key skipped",
-			rule: ":a,b",
-			want: []*rule{},
-		},
 		{
 			name: "all empty values",
 			rule: ";;;",
@@ -72,7 +65,8 @@ func Test_parseRules_Extra(t *testing.T) {
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			got := parseRules(tc.rule)
+			got, err := parseRules(tc.rule)
+			assert.Nil(t, err)
 			assert.Equal(t, tc.want, got)
 		})
 	}
@@ -285,3 +279,89 @@ func Test_AnonymousUnexported_DoesNotPanic(t *testing.T) {
 	assert.False(t, ok)
 	assert.NotEmpty(t, errs)
 }
+
+// =============================================================================
+// HierarchicalLabels — labels composed through nested structs and slices
+// =============================================================================
+
+func Test_HierarchicalLabels(t *testing.T) {
+	type address struct {
+		City string `valid:"required" label:"城市" label-en:"city"`
+	}
+	type spec struct {
+		Color string `valid:"required" label:"颜色" label-en:"color"`
+	}
+	type item struct {
+		Name string `valid:"required" label:"名称" label-en:"name"`
+		Spec spec   `label:"规格" label-en:"spec"`
+	}
+	type order struct {
+		Address address `label:"收货地址" label-en:"Shipping address"`
+		Items   []item  `label:"商品" label-en:"Item"`
+	}
+
+	v := order{
+		Items: []item{
+			{Name: "A", Spec: spec{Color: "red"}},
+			{Name: "B", Spec: spec{Color: "blue"}},
+			{Name: "", Spec: spec{Color: ""}},
+		},
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		errs, ok := Check(v)
+		assert.False(t, ok)
+		assert.Equal(t, 3, len(errs))
+		assert.Equal(t, "城市不能为空", errs[0].Error())
+		assert.Equal(t, "名称不能为空", errs[1].Error())
+	})
+
+	HierarchicalLabels = true
+	defer func() { HierarchicalLabels = false }()
+
+	t.Run("chinese", func(t *testing.T) {
+		errs, ok := Check(v)
+		assert.False(t, ok)
+		assert.Equal(t, 3, len(errs))
+		assert.Equal(t, "收货地址·城市不能为空", errs[0].Error())
+		assert.Equal(t, "第3个商品的名称不能为空", errs[1].Error())
+		assert.Equal(t, "第3个商品的规格·颜色不能为空", errs[2].Error())
+		assert.Equal(t, "第3个商品的名称", errs[1].FieldLabel)
+	})
+
+	t.Run("english", func(t *testing.T) {
+		errs, ok := Check(v, language.English)
+		assert.False(t, ok)
+		assert.Equal(t, "Shipping address city can not be empty", errs[0].Error())
+		assert.Equal(t, "Item #3 name can not be empty", errs[1].Error())
+		assert.Equal(t, "Item #3 spec color can not be empty", errs[2].Error())
+	})
+
+	t.Run("embedded struct adds no level", func(t *testing.T) {
+		errs, _ := Check(derivedForm{Extra: "x"})
+		assert.Equal(t, "基础字段不能为空", errs[0].Error())
+	})
+
+	t.Run("root slice elements", func(t *testing.T) {
+		items := []item{{Name: "A", Spec: spec{Color: "red"}}, {Name: "", Spec: spec{Color: ""}}}
+		errs, _ := Check(items)
+		assert.Equal(t, 2, len(errs))
+		assert.Equal(t, "第2项的名称不能为空", errs[0].Error())
+		assert.Equal(t, "第2项的规格·颜色不能为空", errs[1].Error())
+
+		errs, _ = Check(items, language.English)
+		assert.Equal(t, "#2 name can not be empty", errs[0].Error())
+	})
+
+	t.Run("custom join format", func(t *testing.T) {
+		defer ResetMessageTemplates(language.English)
+		SetMessageTemplates(map[string]string{
+			"_labelNested":  "{parent}.{child}",
+			"_labelElement": "{parent}[{index}].{child}",
+		}, language.English)
+
+		errs, _ := Check(v, language.English)
+		assert.Equal(t, "Shipping address.city can not be empty", errs[0].Error())
+		assert.Equal(t, "Item[3].name can not be empty", errs[1].Error())
+	})
+}
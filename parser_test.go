@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
 )
 
 func Test_parseRules(t *testing.T) {
@@ -29,26 +30,160 @@ func Test_parseRules(t *testing.T) {
 			},
 		},
 		{
-			name: "no value",
-			rule: "required:",
+			name: "empty",
+			rule: "",
+			want: []*rule{},
+		},
+		{
+			name: "quoted params",
+			rule: "list:'a,b','c;d','it''s';required",
 			want: []*rule{
-				{checker: "required", params: []string{""}},
+				{checker: "list", params: []string{"a,b", "c;d", "it's"}},
+				{checker: "required"},
 			},
 		},
 		{
-			name: "nothing",
-			rule: "::::",
-			want: []*rule{},
+			name: "quoted empty param",
+			rule: "list:'',a",
+			want: []*rule{
+				{checker: "list", params: []string{"", "a"}},
+			},
 		},
 		{
-			name: "empty",
-			rule: "",
-			want: []*rule{},
+			name: "backslash escapes",
+			rule: `list:a\,b,c\;d,\'e\',f\ ;required`,
+			want: []*rule{
+				{checker: "list", params: []string{"a,b", "c;d", "'e'", "f "}},
+				{checker: "required"},
+			},
+		},
+		{
+			name: "backslash escapes in quotes",
+			rule: `list:'it\'s','a\\b','\d'`,
+			want: []*rule{
+				{checker: "list", params: []string{"it's", `a\b`, `\d`}},
+			},
+		},
+		{
+			name: "whitespace around tokens",
+			rule: " required ;\tmin : 1 , ' 2 ' ; ",
+			want: []*rule{
+				{checker: "required"},
+				{checker: "min", params: []string{"1", " 2 "}},
+			},
+		},
+		{
+			name: "verbatim regex",
+			rule: "required;regex:^[a-z]+(,[a-z]+)*;?$",
+			want: []*rule{
+				{checker: "required"},
+				{checker: "regex", params: []string{"^[a-z]+(,[a-z]+)*;?$"}},
+			},
+		},
+		{
+			name: "quoted regex",
+			rule: "regex:'^a;b$';maxlen:5",
+			want: []*rule{
+				{checker: "regex", params: []string{"^a;b$"}},
+				{checker: "maxlen", params: []string{"5"}},
+			},
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			got := parseRules(tc.rule)
+			got, err := parseRules(tc.rule)
+			assert.Nil(t, err)
 			assert.Equal(t, tc.want, got)
 		})
 	}
 }
+
+func Test_parseRules_Composite(t *testing.T) {
+	rules, err := parseRules("required; email | mobile ;not:list:admin,root;(tel|mobile);maxlen:20")
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(rules))
+
+	anyOf := rules[1]
+	assert.Equal(t, ruleAny, anyOf.op)
+	assert.Equal(t, "email|mobile", anyOf.checker)
+	assert.Equal(t, []*rule{{checker: "email"}, {checker: "mobile"}}, anyOf.rules)
+
+	not := rules[2]
+	assert.Equal(t, ruleNot, not.op)
+	assert.Equal(t, "not:list:admin,root", not.checker)
+	assert.Equal(t, []*rule{{checker: "list", params: []string{"admin", "root"}}}, not.rules)
+
+	// Parentheses around a single rule only group it.
+	assert.Equal(t, "tel|mobile", rules[3].checker)
+	assert.Equal(t, &rule{checker: "maxlen", params: []string{"20"}}, rules[4])
+
+	for raw, canonical := range map[string]string{
+		"(required ; email) | list:n/a":  "(required;email)|list:n/a",
+		"not:(email|ipv4)":               "not:(email|ipv4)",
+		"(a|b)|c":                        "(a|b)|c",
+		`list:a\|b|list:'c)'`:            `list:'a|b'|list:'c)'`,
+		"(regex:'^a|b$';maxlen:3)|email": "(regex:'^a|b$';maxlen:3)|email",
+		"min:(1)":                        "min:'(1)'",
+		"not :list:admin":                "not:list:admin",
+		"nothing:1":                      "nothing:1",
+	} {
+		rules, err := parseRules(raw)
+		if assert.Nil(t, err, raw) {
+			assert.Equal(t, canonical, rules[0].String(), raw)
+		}
+	}
+}
+
+func Test_parseRules_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		rule   string
+		offset int
+		reason string
+	}{
+		{"missing param", "required;min:", 13, "missing parameter"},
+		{"empty param", "list:a,,b", 7, "missing parameter"},
+		{"missing checker name", "required;:foo", 9, "missing checker name"},
+		{"only colons", "::::", 0, "missing checker name"},
+		{"space in checker name", "requ ired", 5, `unexpected 'i' after checker name`},
+		{"comma after checker name", "required,min:0", 8, `unexpected ',' after checker name`},
+		{"unterminated quote", "list:a,'b;c", 7, "unterminated quoted parameter"},
+		{"text after quote", "list:'a'b", 8, `unexpected 'b' after quoted parameter`},
+		{"missing alternative", "email|", 6, "missing checker name"},
+		{"missing negated rule", "not:;required", 4, "missing checker name"},
+		{"unclosed parenthesis", "required;(tel|mobile", 9, "unclosed parenthesis"},
+		{"unmatched parenthesis", "email)", 5, `unmatched ')'`},
+		{"empty parentheses", "()|email", 0, "empty parentheses"},
+		{"text after group", "(tel|mobile)email", 12, `unexpected 'e' after rule`},
+		{"omitempty in alternatives", "omitempty|email", 0, "applies to the whole field"},
+		{"omitempty as alternative", "required;email| omitempty", 16, "applies to the whole field"},
+		{"negated omitempty", "not: omitempty", 5, "applies to the whole field"},
+		{"omitempty in group", "(email;omitempty)|tel", 7, "applies to the whole field"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseRules(tc.rule)
+			assert.Nil(t, got)
+
+			parseErr, ok := err.(*ParseError)
+			if assert.True(t, ok, "%T", err) {
+				assert.Equal(t, tc.rule, parseErr.Rules)
+				assert.Equal(t, tc.offset, parseErr.Offset)
+				assert.Contains(t, parseErr.Reason, tc.reason)
+			}
+		})
+	}
+}
+
+func Test_Check_RuleSyntaxError(t *testing.T) {
+	type form struct {
+		Name string `valid:"required;maxlen:" label:"Name"`
+		Role string `valid:"list:'admin,user" label:"Role"`
+		Age  int    `valid:"max:18" label:"Age"`
+	}
+
+	errs, ok := Check(form{Age: 3}, language.English)
+	assert.False(t, ok)
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "Name check rule syntax error at column 17", errs[0].Error())
+	assert.Equal(t, "Name", errs[0].FieldPath)
+	assert.Equal(t, "Role check rule syntax error at column 6", errs[1].Error())
+}
@@ -144,9 +144,8 @@ func Test_UsernameUnicodeFirstChar(t *testing.T) {
 	assert.Equal(t, "昵称只含有数字或字母以及下划线", errs[0].Error())
 }
 
-// Test_ListEmptyParams verifies list returns a parameter error when no
-// allowed values are provided, instead of silently rejecting non-empty
-// values.
+// Test_ListEmptyParams verifies list reports an error when no allowed
+// values are provided, instead of silently rejecting non-empty values.
 func Test_ListEmptyParams(t *testing.T) {
 	t.Run("empty params", func(t *testing.T) {
 		v := struct {
@@ -155,7 +154,8 @@ func Test_ListEmptyParams(t *testing.T) {
 		errs, ok := Check(v)
 		assert.False(t, ok)
 		assert.Equal(t, 1, len(errs))
-		assert.Equal(t, "角色检查规则入参错误", errs[0].Error())
+		// A rule with a colon but no params is malformed.
+		assert.Equal(t, "角色检查规则在第6个字符处格式错误", errs[0].Error())
 	})
 }
 
@@ -0,0 +1,450 @@
+package govalid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The rule tag grammar, with whitespace allowed around every token:
+//
+//	rules  = [ expr ] { ";" [ expr ] }
+//	expr   = term { "|" term }
+//	term   = "not:" term | "(" rules ")" | rule
+//	rule   = name [ ":" param { "," param } ]
+//	param  = quoted | bare
+//	quoted = "'" { any byte but "'" and "\", or "\'", "\\", "''" } "'"
+//	bare   = { any byte but "," ";" "|", and ")" inside parentheses,
+//	           or an escape "\," "\;" "\|" "\(" "\)" "\'" "\\" "\ " }
+//
+// A backslash before any other byte is taken literally, so expressions
+// like `\d` need no escaping. The param of a verbatim checker outside
+// parentheses, unless quoted, is the rest of the tag.
+
+// rule is a single validator rule context of a struct field.
+type rule struct {
+	checker string
+	params  []string
+
+	// A composite rule combines its rules with op instead of calling a
+	// checker. Its checker is its canonical text, e.g. "email|mobile",
+	// which is the key of its own template. An alias keeps its name and
+	// params instead.
+	op    ruleOp
+	rules []*rule
+	// message is the template key of an alias's errors, if it has one.
+	message string
+}
+
+// ruleOp is the way a composite rule combines its rules.
+type ruleOp int
+
+const (
+	ruleCheck ruleOp = iota // Not composite: call the checker.
+	ruleAll                 // "(a;b)": every rule must accept the field.
+	ruleAny                 // "a|b": any rule must accept the field.
+	ruleNot                 // "not:a": the rule must reject the field.
+	ruleAlias               // An alias: like ruleAll, see RegisterAlias.
+)
+
+// composite returns the composite rule combining rules with op.
+func composite(op ruleOp, rules []*rule) *rule {
+	r := &rule{op: op, rules: rules}
+	r.checker = r.String()
+	return r
+}
+
+// String returns the canonical text of the rule, which parses back to the
+// same rule.
+func (r *rule) String() string {
+	var b strings.Builder
+	switch r.op {
+	case ruleAll:
+		b.WriteByte('(')
+		for i, sub := range r.rules {
+			if i > 0 {
+				b.WriteByte(';')
+			}
+			b.WriteString(sub.String())
+		}
+		b.WriteByte(')')
+	case ruleAny:
+		for i, sub := range r.rules {
+			if i > 0 {
+				b.WriteByte('|')
+			}
+			b.WriteString(sub.term())
+		}
+	case ruleNot:
+		b.WriteString("not:")
+		b.WriteString(r.rules[0].term())
+	default:
+		b.WriteString(r.checker)
+		for i, param := range r.params {
+			if i == 0 {
+				b.WriteByte(':')
+			} else {
+				b.WriteByte(',')
+			}
+			b.WriteString(quoteParam(param, verbatimCheckers[r.checker]))
+		}
+	}
+	return b.String()
+}
+
+// term returns the canonical text of the rule as a term of a composite
+// rule, which puts OR groups in parentheses.
+func (r *rule) term() string {
+	if r.op == ruleAny {
+		return "(" + r.String() + ")"
+	}
+	return r.String()
+}
+
+// quoteParam returns param as it is written in a tag, quoted if needed or
+// if always is set.
+func quoteParam(param string, always bool) string {
+	if !always && param != "" && strings.IndexAny(param, ",;|()'\\ \t\r\n") < 0 {
+		return param
+	}
+	param = strings.ReplaceAll(param, `\`, `\\`)
+	param = strings.ReplaceAll(param, "'", `\'`)
+	return "'" + param + "'"
+}
+
+// verbatimCheckers take the rest of the tag as their single param, since
+// it may contain ";" and ",". A quoted param ends at its closing quote
+// instead, so more rules can follow it.
+var verbatimCheckers = map[string]bool{
+	"regex": true,
+}
+
+// ParseError describes a malformed rule tag.
+type ParseError struct {
+	Rules  string // The rule tag.
+	Offset int    // Byte offset of the error in Rules.
+	Reason string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parse rules %q: %s at offset %d", e.Rules, e.Reason, e.Offset)
+}
+
+// parseRules parses a rule tag, expanding aliases. It returns a
+// *ParseError if the tag is malformed.
+func parseRules(rawRules string) ([]*rule, error) {
+	s := &ruleScanner{src: rawRules}
+	return s.parse()
+}
+
+// ruleScanner reads a rule tag byte by byte.
+type ruleScanner struct {
+	src string
+	pos int
+	// depth is the number of open parentheses.
+	depth int
+	// expanding are the aliases whose rules are being read, innermost
+	// last.
+	expanding []string
+}
+
+// parse reads the whole tag.
+func (s *ruleScanner) parse() ([]*rule, error) {
+	rules, err := s.rules()
+	if err != nil {
+		return nil, err
+	}
+	if !s.eof() {
+		return nil, s.errorAt(s.pos, "unmatched %q", s.peek())
+	}
+	return rules, nil
+}
+
+func (s *ruleScanner) eof() bool {
+	return s.pos >= len(s.src)
+}
+
+func (s *ruleScanner) peek() byte {
+	return s.src[s.pos]
+}
+
+func (s *ruleScanner) skipSpace() {
+	for !s.eof() && isSpace(s.peek()) {
+		s.pos++
+	}
+}
+
+func (s *ruleScanner) errorAt(offset int, format string, args ...interface{}) error {
+	return &ParseError{
+		Rules:  s.src,
+		Offset: offset,
+		Reason: fmt.Sprintf(format, args...),
+	}
+}
+
+// atEnd reports whether the scanner is at the end of a term: at the end
+// of the tag, or at a ";", "|" or closing parenthesis.
+func (s *ruleScanner) atEnd() bool {
+	if s.eof() {
+		return true
+	}
+	c := s.peek()
+	return c == ';' || c == '|' || c == ')' && s.depth > 0
+}
+
+// rules reads rules up to the end of the tag or of the enclosing
+// parentheses.
+func (s *ruleScanner) rules() ([]*rule, error) {
+	rules := make([]*rule, 0)
+	for {
+		s.skipSpace()
+		if s.eof() || s.peek() == ')' {
+			return rules, nil
+		}
+		if s.peek() == ';' {
+			s.pos++
+			continue
+		}
+
+		start := s.pos
+		r, err := s.expr()
+		if err != nil {
+			return nil, err
+		}
+		if s.depth > 0 && isOmitEmpty(r) {
+			return nil, s.misplacedOmitEmpty(start)
+		}
+		// Outside parentheses, the rules of an alias without a message of
+		// its own are checked as if they were written in its place.
+		if r.op == ruleAlias && r.message == "" && s.depth == 0 {
+			rules = append(rules, r.rules...)
+		} else {
+			rules = append(rules, r)
+		}
+
+		s.skipSpace()
+		if !s.eof() && s.peek() != ';' && s.peek() != ')' {
+			return nil, s.errorAt(s.pos, "unexpected %q after rule", s.peek())
+		}
+	}
+}
+
+// expr reads terms separated by "|".
+func (s *ruleScanner) expr() (*rule, error) {
+	s.skipSpace()
+	starts := []int{s.pos}
+	term, err := s.term()
+	if err != nil {
+		return nil, err
+	}
+	terms := []*rule{term}
+	for {
+		s.skipSpace()
+		if s.eof() || s.peek() != '|' {
+			break
+		}
+		s.pos++
+		s.skipSpace()
+		starts = append(starts, s.pos)
+		term, err := s.term()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+	}
+
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+	for i, term := range terms {
+		if isOmitEmpty(term) {
+			return nil, s.misplacedOmitEmpty(starts[i])
+		}
+	}
+	return composite(ruleAny, terms), nil
+}
+
+// isOmitEmpty reports whether r is the omitempty rule.
+func isOmitEmpty(r *rule) bool {
+	return r.op == ruleCheck && r.checker == OmitEmpty
+}
+
+// misplacedOmitEmpty returns the error of an omitempty rule at offset that
+// is part of another rule, where it would skip nothing.
+func (s *ruleScanner) misplacedOmitEmpty(offset int) error {
+	return s.errorAt(offset, "%s applies to the whole field, not inside \"|\", \"not:\" or parentheses", OmitEmpty)
+}
+
+// term reads a negated term, a group in parentheses or a rule.
+func (s *ruleScanner) term() (*rule, error) {
+	s.skipSpace()
+	start := s.pos
+
+	// Like a checker name, "not" may be followed by spaces before its ":".
+	if end, ok := s.negation(); ok {
+		s.pos = end
+		s.skipSpace()
+		termStart := s.pos
+		term, err := s.term()
+		if err != nil {
+			return nil, err
+		}
+		if isOmitEmpty(term) {
+			return nil, s.misplacedOmitEmpty(termStart)
+		}
+		return composite(ruleNot, []*rule{term}), nil
+	}
+
+	if !s.eof() && s.peek() == '(' {
+		s.pos++
+		s.depth++
+		rules, err := s.rules()
+		if err != nil {
+			return nil, err
+		}
+		if s.eof() {
+			return nil, s.errorAt(start, "unclosed parenthesis")
+		}
+		s.pos++
+		s.depth--
+
+		switch len(rules) {
+		case 0:
+			return nil, s.errorAt(start, "empty parentheses")
+		case 1:
+			return rules[0], nil
+		}
+		return composite(ruleAll, rules), nil
+	}
+
+	r, err := s.rule()
+	if err != nil {
+		return nil, err
+	}
+	return s.expandAlias(r, start)
+}
+
+// negation reports whether a "not:" negation starts at the current
+// position, and where the negated term starts.
+func (s *ruleScanner) negation() (int, bool) {
+	if !strings.HasPrefix(s.src[s.pos:], "not") {
+		return 0, false
+	}
+	end := s.pos + len("not")
+	for end < len(s.src) && isSpace(s.src[end]) {
+		end++
+	}
+	if end >= len(s.src) || s.src[end] != ':' {
+		return 0, false
+	}
+	return end + 1, true
+}
+
+// rule reads a rule up to the end of its term.
+func (s *ruleScanner) rule() (*rule, error) {
+	start := s.pos
+	for !s.eof() && !isSpace(s.peek()) && strings.IndexByte(":;,|()'\\", s.peek()) < 0 {
+		s.pos++
+	}
+	r := &rule{checker: s.src[start:s.pos]}
+	if r.checker == "" {
+		return nil, s.errorAt(start, "missing checker name")
+	}
+
+	s.skipSpace()
+	if s.atEnd() || s.peek() == ')' {
+		return r, nil
+	}
+	if s.peek() != ':' {
+		return nil, s.errorAt(s.pos, "unexpected %q after checker name", s.peek())
+	}
+	s.pos++
+
+	s.skipSpace()
+	if verbatimCheckers[r.checker] && s.depth == 0 && !s.eof() && s.peek() != '\'' {
+		r.params = []string{strings.TrimRight(s.src[s.pos:], " \t\r\n")}
+		s.pos = len(s.src)
+		return r, nil
+	}
+
+	for {
+		param, err := s.param()
+		if err != nil {
+			return nil, err
+		}
+		r.params = append(r.params, param)
+
+		if s.eof() || s.peek() != ',' {
+			return r, nil
+		}
+		s.pos++
+	}
+}
+
+// param reads a param up to the end of its term or the "," following it.
+func (s *ruleScanner) param() (string, error) {
+	s.skipSpace()
+	start := s.pos
+
+	if !s.eof() && s.peek() == '\'' {
+		param, err := s.quoted()
+		if err != nil {
+			return "", err
+		}
+		s.skipSpace()
+		if !s.atEnd() && s.peek() != ',' {
+			return "", s.errorAt(s.pos, "unexpected %q after quoted parameter", s.peek())
+		}
+		return param, nil
+	}
+
+	var param strings.Builder
+	// Trailing whitespace is trimmed, unless it was escaped.
+	keep := 0
+	for !s.atEnd() && s.peek() != ',' {
+		if s.peek() == '\\' && s.pos+1 < len(s.src) && strings.IndexByte(",;|()'\\ ", s.src[s.pos+1]) >= 0 {
+			param.WriteByte(s.src[s.pos+1])
+			s.pos += 2
+			keep = param.Len()
+			continue
+		}
+		param.WriteByte(s.peek())
+		s.pos++
+	}
+
+	value := param.String()
+	value = value[:keep] + strings.TrimRight(value[keep:], " \t\r\n")
+	if value == "" {
+		return "", s.errorAt(start, "missing parameter, quote an empty one as ''")
+	}
+	return value, nil
+}
+
+// quoted reads a single quoted param.
+func (s *ruleScanner) quoted() (string, error) {
+	start := s.pos
+	s.pos++
+
+	var param strings.Builder
+	for !s.eof() {
+		c := s.peek()
+		switch {
+		case c == '\\' && s.pos+1 < len(s.src) && (s.src[s.pos+1] == '\'' || s.src[s.pos+1] == '\\'):
+			param.WriteByte(s.src[s.pos+1])
+			s.pos += 2
+		case c == '\'' && s.pos+1 < len(s.src) && s.src[s.pos+1] == '\'':
+			param.WriteByte('\'')
+			s.pos += 2
+		case c == '\'':
+			s.pos++
+			return param.String(), nil
+		default:
+			param.WriteByte(c)
+			s.pos++
+		}
+	}
+	return "", s.errorAt(start, "unterminated quoted parameter")
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
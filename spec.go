@@ -0,0 +1,135 @@
+package govalid
+
+import (
+	"reflect"
+	"sort"
+)
+
+// ParamType names what a checker param must be. Compile checks params
+// against it.
+type ParamType string
+
+const (
+	ParamString  ParamType = "string"  // Any text.
+	ParamInt     ParamType = "int"     // An integer.
+	ParamFloat   ParamType = "float"   // A floating-point number.
+	ParamBool    ParamType = "bool"    // A boolean as in strconv.ParseBool.
+	ParamNumber  ParamType = "number"  // A number of the field's type, or a duration for time.Duration fields.
+	ParamBound   ParamType = "bound"   // A number for numbers and numeric strings, a time otherwise.
+	ParamTime    ParamType = "time"    // An absolute time or one relative to "now" or "today".
+	ParamField   ParamType = "field"   // A field reference, see CheckerContext.LookupField.
+	ParamRegex   ParamType = "regex"   // A regular expression.
+	ParamPattern ParamType = "pattern" // The name of a pattern registered with RegisterPattern.
+	ParamWeekday ParamType = "weekday" // A weekday name, abbreviation or number.
+	ParamLayout  ParamType = "layout"  // A time.Parse layout.
+
+	ParamDuration ParamType = "duration" // A duration in nanoseconds or time.ParseDuration syntax.
+)
+
+// ParamSchema describes the params a checker takes. Param i has the type
+// Types[i%len(Types)].
+type ParamSchema struct {
+	Min int // The minimum number of params.
+	Max int // The maximum number of params, unless Variadic.
+
+	// Variadic checkers take any number of params from Min on, in whole
+	// repetitions of Types.
+	Variadic bool
+	Types    []ParamType
+}
+
+// CheckerSpec describes a checker.
+type CheckerSpec struct {
+	Name   string
+	Func   CheckFunc
+	Params ParamSchema
+
+	// Kinds are the kinds of field values the checker applies to, after
+	// dereferencing pointers. Empty means any kind. Struct only covers leaf
+	// types, see RegisterLeafType.
+	Kinds []reflect.Kind
+
+	// MessageKey is the key of the template the checker's errors use by
+	// default, i.e. the one NewErrorContext picks. It defaults to Name.
+	MessageKey  string
+	Description string
+
+	// args are the argument types that the params of a checker registered
+	// with RegisterParamFunc are parsed as, in the order of Params.Types.
+	args []reflect.Type
+}
+
+var (
+	stringKinds = []reflect.Kind{reflect.String}
+	lengthKinds = []reflect.Kind{reflect.String, reflect.Slice, reflect.Array, reflect.Map}
+	// Numbers include big.Int, big.Float and big.Rat, and decimal strings.
+	numberKinds = []reflect.Kind{
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String, reflect.Struct,
+	}
+	// Times are time.Time values and time strings.
+	timeKinds = []reflect.Kind{reflect.Struct, reflect.String}
+)
+
+// checkerSpecs holds the specs of the checkers registered with
+// RegisterChecker.
+var checkerSpecs = map[string]CheckerSpec{}
+
+// RegisterChecker registers a checker along with its spec, replacing any
+// checker of the same name.
+//
+// Example:
+//
+//	govalid.RegisterChecker(govalid.CheckerSpec{
+//		Name:        "prefix",
+//		Func:        prefix,
+//		Params:      govalid.ParamSchema{Min: 1, Max: 1, Types: []govalid.ParamType{govalid.ParamString}},
+//		Kinds:       []reflect.Kind{reflect.String},
+//		Description: "Must start with the param.",
+//	})
+func RegisterChecker(spec CheckerSpec) {
+	if spec.MessageKey == "" {
+		spec.MessageKey = spec.Name
+	}
+	Checkers[spec.Name] = spec.Func
+	checkerSpecs[spec.Name] = spec
+}
+
+// Describe returns the spec of the named checker. A checker assigned to
+// Checkers directly is described as taking any string params.
+func Describe(name string) (CheckerSpec, bool) {
+	fn, ok := Checkers[name]
+	if !ok {
+		return CheckerSpec{}, false
+	}
+
+	spec, ok := checkerSpecs[name]
+	if !ok {
+		spec = CheckerSpec{
+			Name:       name,
+			Params:     ParamSchema{Variadic: true, Types: []ParamType{ParamString}},
+			MessageKey: name,
+		}
+	}
+	spec.Func = fn
+	spec.Params.Types = append([]ParamType(nil), spec.Params.Types...)
+	spec.Kinds = append([]reflect.Kind(nil), spec.Kinds...)
+	return spec, true
+}
+
+// ListCheckers returns the specs of all checkers, sorted by name.
+func ListCheckers() []CheckerSpec {
+	names := make([]string, 0, len(Checkers))
+	for name := range Checkers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	specs := make([]CheckerSpec, 0, len(names))
+	for _, name := range names {
+		spec, _ := Describe(name)
+		specs = append(specs, spec)
+	}
+	return specs
+}
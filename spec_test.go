@@ -0,0 +1,151 @@
+package govalid
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Describe(t *testing.T) {
+	t.Run("built-in checker", func(t *testing.T) {
+		spec, ok := Describe("min")
+		assert.True(t, ok)
+		assert.Equal(t, "min", spec.Name)
+		assert.NotNil(t, spec.Func)
+		assert.Equal(t, ParamSchema{Min: 1, Max: 1, Types: []ParamType{ParamNumber}}, spec.Params)
+		assert.Contains(t, spec.Kinds, reflect.Int)
+		assert.Equal(t, "min", spec.MessageKey)
+		assert.NotEmpty(t, spec.Description)
+	})
+
+	t.Run("message key of conditional checkers", func(t *testing.T) {
+		spec, _ := Describe("required_if")
+		assert.Equal(t, "required", spec.MessageKey)
+		assert.True(t, spec.Params.Variadic)
+		assert.Equal(t, []ParamType{ParamField, ParamString}, spec.Params.Types)
+	})
+
+	t.Run("returns copies", func(t *testing.T) {
+		spec, _ := Describe("email")
+		spec.Kinds[0] = reflect.Bool
+		spec, _ = Describe("email")
+		assert.Equal(t, []reflect.Kind{reflect.String}, spec.Kinds)
+	})
+
+	t.Run("checker assigned to Checkers", func(t *testing.T) {
+		Checkers["shout"] = func(c CheckerContext) *ErrContext { return nil }
+		defer delete(Checkers, "shout")
+
+		spec, ok := Describe("shout")
+		assert.True(t, ok)
+		assert.NotNil(t, spec.Func)
+		assert.Equal(t, "shout", spec.MessageKey)
+		assert.True(t, spec.Params.Variadic)
+		assert.Empty(t, spec.Kinds)
+	})
+
+	t.Run("unknown checker", func(t *testing.T) {
+		_, ok := Describe("nope")
+		assert.False(t, ok)
+	})
+}
+
+func Test_ListCheckers(t *testing.T) {
+	specs := ListCheckers()
+	assert.Equal(t, len(Checkers), len(specs))
+	for i := 1; i < len(specs); i++ {
+		assert.Less(t, specs[i-1].Name, specs[i].Name)
+	}
+
+	// Every built-in checker has a template for its message key, except
+	// omitempty and -type, which never fail.
+	for _, spec := range specs {
+		if spec.Name == OmitEmpty || spec.Name == NoTypeRules {
+			continue
+		}
+		_, ok := MessageTemplate(spec.MessageKey)
+		assert.True(t, ok, spec.Name)
+	}
+}
+
+func Test_RegisterChecker(t *testing.T) {
+	prefix := func(c CheckerContext) *ErrContext {
+		if !strings.HasPrefix(c.FieldValue.(string), c.Rule.params[0]) {
+			return NewErrorContext(c)
+		}
+		return nil
+	}
+	RegisterChecker(CheckerSpec{
+		Name:   "prefix",
+		Func:   prefix,
+		Params: ParamSchema{Min: 1, Max: 1, Types: []ParamType{ParamString}},
+		Kinds:  []reflect.Kind{reflect.String},
+	})
+	defer func() {
+		delete(Checkers, "prefix")
+		delete(checkerSpecs, "prefix")
+	}()
+
+	spec, ok := Describe("prefix")
+	assert.True(t, ok)
+	assert.Equal(t, "prefix", spec.MessageKey)
+
+	type form struct {
+		Code  string `valid:"prefix:SKU-"`
+		Count int    `valid:"prefix:1"`
+		Name  string `valid:"prefix"`
+	}
+	err := Compile(reflect.TypeOf(form{}))
+	if assert.NotNil(t, err) {
+		assert.Equal(t, "2 invalid rules in govalid.form:\n"+
+			"\tCount: prefix: does not apply to int fields\n"+
+			"\tName: prefix: takes 1 params, got 0", err.Error())
+	}
+
+	v := struct {
+		Code string `valid:"prefix:SKU-" label:"编码"`
+	}{Code: "ABC-1"}
+	assert.Nil(t, Compile(reflect.TypeOf(v)))
+	errs, _ := Check(v)
+	assert.Equal(t, 1, len(errs))
+}
+
+func Test_RegisterChecker_MessageKey(t *testing.T) {
+	RegisterChecker(CheckerSpec{
+		Name:       "neverok",
+		Func:       func(c CheckerContext) *ErrContext { return NewErrorContext(c) },
+		MessageKey: "required",
+	})
+	defer func() {
+		delete(Checkers, "neverok")
+		delete(checkerSpecs, "neverok")
+	}()
+
+	errs, _ := Check(struct {
+		Name string `valid:"neverok" label:"名字"`
+	}{})
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "名字不能为空", errs[0].Error())
+}
+
+func Test_Compile_Kinds(t *testing.T) {
+	type form struct {
+		Email   sql.NullString `valid:"email"`
+		Name    *string        `valid:"alpha"`
+		Any     interface{}    `valid:"maxlen:3"`
+		Tags    []string       `valid:"maxlen:3"`
+		Flag    bool           `valid:"min:1"`
+		Created int64          `valid:"before:now"`
+	}
+
+	err := Compile(reflect.TypeOf(form{}))
+	compileErr, ok := err.(*CompileError)
+	if assert.True(t, ok, "%T", err) {
+		assert.Equal(t, 2, len(compileErr.Errors))
+		assert.Equal(t, "Flag", compileErr.Errors[0].FieldPath)
+		assert.Equal(t, "Created", compileErr.Errors[1].FieldPath)
+	}
+}
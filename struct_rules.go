@@ -0,0 +1,296 @@
+package govalid
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"golang.org/x/text/language"
+)
+
+// structRules holds what RegisterRules and RegisterStructValidation
+// registered for a struct type.
+type structRules struct {
+	// rules are the registered rules of the fields, by field name.
+	rules map[string]string
+	// override marks the fields whose own tag rules are replaced.
+	override map[string]bool
+	// labels are the registered labels of the fields by language, with
+	// language.Und for the plain labels.
+	labels      map[language.Tag]map[string]string
+	validations []func(StructLevel)
+}
+
+// registeredStructs is the registry of struct types with registered rules
+// or validations.
+var registeredStructs = map[reflect.Type]*structRules{}
+
+// registeredStruct returns the registration of the struct type typ,
+// creating it if needed.
+func registeredStruct(typ reflect.Type) *structRules {
+	s, ok := registeredStructs[typ]
+	if !ok {
+		s = &structRules{
+			rules:    make(map[string]string),
+			override: make(map[string]bool),
+			labels:   make(map[language.Tag]map[string]string),
+		}
+		registeredStructs[typ] = s
+	}
+	return s
+}
+
+// structTypeOf returns the struct type of v, or of what v points to.
+func structTypeOf(v interface{}) (reflect.Type, error) {
+	typ := reflect.TypeOf(v)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%T is not a struct", v)
+	}
+	return typ, nil
+}
+
+// RulesOption configures a RegisterRules call.
+type RulesOption func(*rulesOptions)
+
+type rulesOptions struct {
+	labels   map[language.Tag]map[string]string
+	override bool
+}
+
+// WithLabels sets the labels of fields by field name, for the given
+// language or, without one, for every language. They take precedence over
+// label tags.
+func WithLabels(labels map[string]string, lang ...language.Tag) RulesOption {
+	return func(o *rulesOptions) {
+		tag := language.Und
+		if len(lang) > 0 {
+			tag = lang[0]
+		}
+		o.labels[tag] = labels
+	}
+}
+
+// OverrideTags makes the registered rules replace the fields' own valid
+// tags rather than being checked after them.
+func OverrideTags() RulesOption {
+	return func(o *rulesOptions) {
+		o.override = true
+	}
+}
+
+// RegisterRules registers rules for the fields of the struct type of v, by
+// field name, for types that can't be given valid tags, such as generated
+// or third-party types. They apply wherever the type is checked: as the
+// checked value, a nested struct or a slice element, directly or through a
+// pointer. The rules of a field
+// are checked after its own tag rules, or replace them with OverrideTags.
+// Registering rules for a field again replaces them.
+//
+// Example:
+//
+//	govalid.RegisterRules(pb.User{}, map[string]string{
+//		"Name":  "required;maxlen:32",
+//		"Email": "required;email",
+//	}, govalid.WithLabels(map[string]string{"Name": "姓名", "Email": "邮箱"}))
+func RegisterRules(v interface{}, rules map[string]string, opts ...RulesOption) error {
+	typ, err := structTypeOf(v)
+	if err != nil {
+		return fmt.Errorf("register rules: %v", err)
+	}
+	o := &rulesOptions{labels: make(map[language.Tag]map[string]string)}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	for name, raw := range rules {
+		if err := checkFieldName(typ, name); err != nil {
+			return fmt.Errorf("register rules of %s: %v", typ, err)
+		}
+		if _, err := parseRules(raw); err != nil {
+			return fmt.Errorf("register rules of %s.%s: %v", typ, name, err)
+		}
+	}
+	for _, labels := range o.labels {
+		for name := range labels {
+			if err := checkFieldName(typ, name); err != nil {
+				return fmt.Errorf("register labels of %s: %v", typ, err)
+			}
+		}
+	}
+
+	s := registeredStruct(typ)
+	for name, raw := range rules {
+		s.rules[name] = raw
+		s.override[name] = o.override
+	}
+	for tag, labels := range o.labels {
+		if s.labels[tag] == nil {
+			s.labels[tag] = make(map[string]string, len(labels))
+		}
+		for name, label := range labels {
+			s.labels[tag][name] = label
+		}
+	}
+	return nil
+}
+
+// checkFieldName checks that the struct type typ declares an exported
+// field of the given name.
+func checkFieldName(typ reflect.Type, name string) error {
+	field, ok := typ.FieldByName(name)
+	if !ok || field.PkgPath != "" {
+		return fmt.Errorf("no exported field %q", name)
+	}
+	if len(field.Index) > 1 {
+		return fmt.Errorf("field %q is promoted, register it on the embedded type", name)
+	}
+	return nil
+}
+
+// RegisterStructValidation registers fn to validate the struct type of v
+// as a whole, for logic across fields. Like RegisterRules, it applies
+// wherever the type is checked; fn runs after the tag rules of the struct's
+// fields and reports errors through sl.
+//
+// Example:
+//
+//	govalid.RegisterStructValidation(pb.Range{}, func(sl govalid.StructLevel) {
+//		r := sl.Current().Interface().(pb.Range)
+//		if r.Start > r.End {
+//			sl.ReportError("End", "gtefield", sl.Label("Start"))
+//		}
+//	})
+func RegisterStructValidation(v interface{}, fn func(sl StructLevel)) error {
+	typ, err := structTypeOf(v)
+	if err != nil {
+		return fmt.Errorf("register struct validation: %v", err)
+	}
+	if fn == nil {
+		return fmt.Errorf("register struct validation of %s: nil function", typ)
+	}
+	s := registeredStruct(typ)
+	s.validations = append(s.validations, fn)
+	return nil
+}
+
+// fieldRuleTags returns the rule tags of a field of the struct type owner:
+// its own valid tag and the rules registered for it. It returns nil if the
+// field has neither.
+func fieldRuleTags(owner reflect.Type, field reflect.StructField) []string {
+	tags := make([]string, 0, 2)
+	own, hasOwn := field.Tag.Lookup(RulesField)
+
+	s, ok := registeredStructs[owner]
+	registered, hasRegistered := "", false
+	if ok {
+		registered, hasRegistered = s.rules[field.Name]
+	}
+
+	if hasOwn && !(hasRegistered && s.override[field.Name]) {
+		tags = append(tags, own)
+	}
+	if hasRegistered {
+		tags = append(tags, registered)
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+	return tags
+}
+
+// registeredLabel returns the label registered for a field of the struct
+// type owner in the given language.
+func registeredLabel(owner reflect.Type, name string, languageTag language.Tag) (string, bool) {
+	s, ok := registeredStructs[owner]
+	if !ok {
+		return "", false
+	}
+	if label, ok := s.labels[languageTag][name]; ok {
+		return label, true
+	}
+	label, ok := s.labels[language.Und][name]
+	return label, ok
+}
+
+// StructLevel is what a struct validation registered with
+// RegisterStructValidation sees of the struct being checked.
+type StructLevel interface {
+	// Context is the context passed to CheckContext.
+	Context() context.Context
+	// Current is the struct being validated.
+	Current() reflect.Value
+	// Parent is the struct enclosing Current, or the zero reflect.Value.
+	Parent() reflect.Value
+	// Root is the value passed to Check.
+	Root() reflect.Value
+	// Path is the path of Current from Root, e.g. "Items[2]".
+	Path() string
+	// Language is the language of the error messages.
+	Language() language.Tag
+
+	// Label returns the label of a field of Current, which may be a field
+	// reference as for CheckerContext.LookupField.
+	Label(field string) string
+	// ReportError reports an error on a field of Current with the template
+	// of key. limit is the {limit} of the template, or nil.
+	ReportError(field, key string, limit interface{})
+}
+
+type structLevel struct {
+	ctx  context.Context
+	node *structNode
+	root reflect.Value
+	opts *checkOptions
+	errs []*ErrContext
+}
+
+func (sl *structLevel) Context() context.Context { return sl.ctx }
+func (sl *structLevel) Current() reflect.Value   { return sl.node.value }
+func (sl *structLevel) Parent() reflect.Value    { return sl.node.parentValue() }
+func (sl *structLevel) Root() reflect.Value      { return sl.root }
+func (sl *structLevel) Path() string             { return sl.node.path }
+func (sl *structLevel) Language() language.Tag   { return sl.opts.language }
+
+// fieldContext returns the checker context of a field of Current.
+func (sl *structLevel) fieldContext(field string) CheckerContext {
+	c := CheckerContext{
+		Context:          sl.ctx,
+		StructValue:      sl.node.value,
+		Parent:           sl.node.parentValue(),
+		Root:             sl.root,
+		FieldName:        field,
+		FieldPath:        joinPath(sl.node.path, field),
+		FieldLabel:       sl.node.scope.label(field),
+		Index:            sl.node.index,
+		StrictEmpty:      sl.opts.strictEmpty,
+		TemplateLanguage: sl.opts.language,
+
+		node: sl.node,
+	}
+
+	value, owner, structField, ok := c.lookupField(field)
+	if ok {
+		c.FieldName = structField.Name
+		c.FieldType = structField.Type
+		c.FieldValue = value.Interface()
+		c.FieldLabel = sl.node.scope.label(fieldLabel(owner, structField, sl.opts.language))
+	}
+	return c
+}
+
+func (sl *structLevel) Label(field string) string {
+	return sl.fieldContext(field).FieldLabel
+}
+
+func (sl *structLevel) ReportError(field, key string, limit interface{}) {
+	c := sl.fieldContext(field)
+	c.Rule = &rule{checker: key}
+	errCtx := NewErrorContext(c)
+	if limit != nil {
+		errCtx.SetFieldLimitValue(limit)
+	}
+	sl.errs = append(sl.errs, errCtx)
+}
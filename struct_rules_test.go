@@ -0,0 +1,215 @@
+package govalid
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// Stand-ins for generated types that can't be tagged.
+type generatedUser struct {
+	Name  string
+	Email string `valid:"email"`
+	Age   int
+}
+
+type generatedRange struct {
+	Start int
+	End   int
+}
+
+type generatedOrder struct {
+	Buyer  generatedUser
+	Ranges []generatedRange
+}
+
+// generatedMessage nests messages through pointers, as protoc-gen-go does.
+type generatedMessage struct {
+	Buyer  *generatedUser
+	Ranges []*generatedRange
+	Next   *generatedMessage
+}
+
+// unregisterStruct drops the registrations of the struct type of v at the
+// end of a test.
+func unregisterStruct(t *testing.T, v interface{}) {
+	t.Cleanup(func() { delete(registeredStructs, reflect.TypeOf(v)) })
+}
+
+// =============================================================================
+// RegisterRules
+// =============================================================================
+
+func Test_RegisterRules(t *testing.T) {
+	unregisterStruct(t, generatedUser{})
+	err := RegisterRules(generatedUser{}, map[string]string{
+		"Name":  "required;maxlen:4",
+		"Email": "required",
+	}, WithLabels(map[string]string{"Name": "姓名", "Email": "邮箱"}),
+		WithLabels(map[string]string{"Name": "Name"}, language.English))
+	assert.Nil(t, err)
+
+	_, ok := Check(generatedUser{Name: "Bob", Email: "bob@example.com"})
+	assert.True(t, ok)
+
+	// The registered rules are checked after the tag's.
+	errs, _ := Check(&generatedUser{Name: "Alice", Email: "bob"})
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "姓名长度应小于4", errs[0].Error())
+	assert.Equal(t, "邮箱不是合法的电子邮箱格式", errs[1].Error())
+
+	errs, _ = Check(generatedUser{}, language.English)
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "Name can not be empty", errs[0].Error())
+	assert.Equal(t, "邮箱 can not be empty", errs[1].Error())
+
+	// Nested structs and slice elements get the rules too.
+	errs, _ = Check(generatedOrder{Buyer: generatedUser{Name: "Bob"}})
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "Buyer.Email", errs[0].FieldPath)
+	errs, _ = Check([]generatedUser{{Name: "Bob", Email: "bob@example.com"}, {Email: "a@example.com"}})
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "[1].Name", errs[0].FieldPath)
+}
+
+func Test_RegisterRules_OverrideTags(t *testing.T) {
+	unregisterStruct(t, generatedUser{})
+	assert.Nil(t, RegisterRules(generatedUser{}, map[string]string{"Email": "maxlen:3"}, OverrideTags()))
+
+	errs, _ := Check(generatedUser{Email: "abcd"})
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "Email长度应小于3", errs[0].Error())
+}
+
+func Test_RegisterRules_Invalid(t *testing.T) {
+	unregisterStruct(t, generatedUser{})
+	type embedding struct {
+		generatedUser
+		private string
+	}
+
+	for _, err := range []error{
+		RegisterRules("user", map[string]string{"Name": "required"}),
+		RegisterRules(generatedUser{}, map[string]string{"Nickname": "required"}),
+		RegisterRules(generatedUser{}, map[string]string{"Name": "min:"}),
+		RegisterRules(generatedUser{}, nil, WithLabels(map[string]string{"Nickname": "昵称"})),
+		RegisterRules(embedding{}, map[string]string{"Name": "required"}),
+		RegisterRules(embedding{}, map[string]string{"private": "required"}),
+	} {
+		assert.NotNil(t, err)
+	}
+	assert.NotContains(t, registeredStructs, reflect.TypeOf(generatedUser{}))
+}
+
+func Test_Compile_RegisterRules(t *testing.T) {
+	unregisterStruct(t, generatedUser{})
+	assert.Nil(t, RegisterRules(generatedUser{}, map[string]string{"Age": "minlen:1"}))
+
+	err := Compile(reflect.TypeOf(generatedOrder{}))
+	if assert.NotNil(t, err) {
+		errs := err.(*CompileError).Errors
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "Buyer.Age", errs[0].FieldPath)
+		assert.Equal(t, "minlen", errs[0].Checker)
+	}
+}
+
+func Test_RegisterRules_Pointers(t *testing.T) {
+	unregisterStruct(t, generatedUser{})
+	unregisterStruct(t, generatedRange{})
+	assert.Nil(t, RegisterRules(generatedUser{}, map[string]string{"Name": "required"}))
+	assert.Nil(t, RegisterStructValidation(generatedRange{}, func(sl StructLevel) {
+		r := sl.Current().Interface().(generatedRange)
+		if r.Start > r.End {
+			sl.ReportError("End", "gtefield", "Start")
+		}
+	}))
+
+	// Nil pointers have nothing to check.
+	_, ok := Check(&generatedMessage{Ranges: []*generatedRange{nil, {1, 2}}})
+	assert.True(t, ok)
+
+	// A cycle of pointers is walked once.
+	m := &generatedMessage{Buyer: &generatedUser{}, Ranges: []*generatedRange{nil, {5, 4}}}
+	m.Next = m
+	errs, _ := Check(m)
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "Buyer.Name", errs[0].FieldPath)
+	assert.Equal(t, "Ranges[1].End", errs[1].FieldPath)
+
+	errs, _ = Check([]*generatedUser{{Name: "Bob"}, nil, {}})
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "[2].Name", errs[0].FieldPath)
+}
+
+func Test_Compile_RegisterRules_Pointers(t *testing.T) {
+	unregisterStruct(t, generatedUser{})
+	assert.Nil(t, RegisterRules(generatedUser{}, map[string]string{"Age": "minlen:1"}))
+
+	for _, typ := range []reflect.Type{reflect.TypeOf(generatedMessage{}), reflect.TypeOf([]*generatedUser{})} {
+		err := Compile(typ)
+		if assert.NotNil(t, err, "%s", typ) {
+			errs := err.(*CompileError).Errors
+			assert.Equal(t, 1, len(errs))
+			assert.Equal(t, "minlen", errs[0].Checker)
+		}
+	}
+}
+
+// =============================================================================
+// RegisterStructValidation
+// =============================================================================
+
+func Test_RegisterStructValidation(t *testing.T) {
+	unregisterStruct(t, generatedRange{})
+	assert.Nil(t, RegisterRules(generatedRange{}, nil, WithLabels(map[string]string{"Start": "开始", "End": "结束"})))
+	err := RegisterStructValidation(generatedRange{}, func(sl StructLevel) {
+		r := sl.Current().Interface().(generatedRange)
+		if r.Start > r.End {
+			sl.ReportError("End", "gtefield", sl.Label("Start"))
+		}
+	})
+	assert.Nil(t, err)
+
+	_, ok := Check(generatedRange{Start: 1, End: 2})
+	assert.True(t, ok)
+
+	errs, _ := Check(generatedRange{Start: 3, End: 2})
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "结束应大于或等于开始", errs[0].Error())
+	assert.Equal(t, "End", errs[0].FieldPath)
+	assert.Equal(t, 2, errs[0].FieldValue)
+
+	errs, _ = Check(generatedOrder{Ranges: []generatedRange{{1, 2}, {5, 4}}}, language.English)
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "Ranges[1].End", errs[0].FieldPath)
+	assert.Equal(t, "结束 should be greater than or equal to 开始", errs[0].Error())
+
+	assert.NotNil(t, RegisterStructValidation(0, func(sl StructLevel) {}))
+	assert.NotNil(t, RegisterStructValidation(generatedRange{}, nil))
+}
+
+func Test_RegisterStructValidation_Order(t *testing.T) {
+	unregisterStruct(t, generatedUser{})
+	var paths []string
+	assert.Nil(t, RegisterStructValidation(generatedUser{}, func(sl StructLevel) {
+		paths = append(paths, sl.Path())
+		assert.Equal(t, language.English, sl.Language())
+		if sl.Current().FieldByName("Age").Int() < 18 {
+			sl.ReportError("Age", "min", 18)
+		}
+	}))
+
+	type form struct {
+		Buyer  generatedUser
+		Seller generatedUser
+		Note   string `valid:"required"`
+	}
+	errs, _ := Check(form{Buyer: generatedUser{Age: 17}, Seller: generatedUser{Age: 30}}, language.English)
+	assert.Equal(t, []string{"Buyer", "Seller"}, paths)
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "Buyer.Age", errs[0].FieldPath)
+	assert.Equal(t, "Note", errs[1].FieldPath)
+}
@@ -1,6 +1,10 @@
 package govalid
 
-import "golang.org/x/text/language"
+import (
+	"sort"
+
+	"golang.org/x/text/language"
+)
 
 // SetMessageTemplates merges the given templates into the current language's
 // template set, overriding any existing entries. The language defaults to
@@ -13,10 +17,7 @@ import "golang.org/x/text/language"
 //	    "min":      "must be greater than",
 //	})
 func SetMessageTemplates(templates map[string]string, lang ...language.Tag) {
-	tag := defaultTemplateLanguage
-	if len(lang) > 0 {
-		tag = lang[0]
-	}
+	tag := templateLanguageOf(lang)
 
 	target, ok := errorTemplateSet[tag]
 	if !ok {
@@ -28,6 +29,141 @@ func SetMessageTemplates(templates map[string]string, lang ...language.Tag) {
 	}
 }
 
+// MessageTemplate returns the template registered for key in the given
+// language, without any fallback. The language defaults to the package
+// default (Chinese) when no language is specified.
+func MessageTemplate(key string, lang ...language.Tag) (string, bool) {
+	template, ok := errorTemplateSet[templateLanguageOf(lang)][key]
+	return template, ok
+}
+
+// MessageTemplates returns a copy of the template set registered for the
+// given language, or nil if the language has no templates. Modifying the
+// returned map does not affect validation; use SetMessageTemplates instead.
+func MessageTemplates(lang ...language.Tag) map[string]string {
+	target, ok := errorTemplateSet[templateLanguageOf(lang)]
+	if !ok {
+		return nil
+	}
+	return copyTemplates(target)
+}
+
+// Languages returns the languages that have a template set registered,
+// sorted by their BCP 47 string.
+func Languages() []language.Tag {
+	tags := make([]language.Tag, 0, len(errorTemplateSet))
+	for tag := range errorTemplateSet {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].String() < tags[j].String()
+	})
+	return tags
+}
+
+// ResetMessageTemplates restores the bundled templates of the given
+// language, dropping every override made through SetMessageTemplates or
+// RemoveMessageTemplate. The templates of checkers registered with
+// RegisterFunc or RegisterParamFunc are restored too. A language that has
+// neither is unregistered.
+func ResetMessageTemplates(lang ...language.Tag) {
+	tag := templateLanguageOf(lang)
+
+	bundled, isBundled := bundledTemplateSet[tag]
+	registered, isRegistered := checkerTemplateSet[tag]
+	if !isBundled && !isRegistered {
+		delete(errorTemplateSet, tag)
+		return
+	}
+
+	// Reset in place so that references to the bundled maps stay valid.
+	target, ok := errorTemplateSet[tag]
+	if !ok {
+		target = make(map[string]string, len(bundled)+len(registered))
+		errorTemplateSet[tag] = target
+	}
+	for k := range target {
+		delete(target, k)
+	}
+	for k, v := range bundled {
+		target[k] = v
+	}
+	for k, v := range registered {
+		target[k] = v
+	}
+}
+
+// RemoveMessageTemplate removes the template of key from the given
+// language. Subsequent errors for that key render the unknown error
+// template until it is set again.
+func RemoveMessageTemplate(key string, lang ...language.Tag) {
+	if target, ok := errorTemplateSet[templateLanguageOf(lang)]; ok {
+		delete(target, key)
+	}
+}
+
+// MissingTemplates returns the sorted template keys that are present in the
+// default language's template set but missing in the given language's own
+// set. Fallbacks are not taken into account, so a locale that has not been
+// registered at all reports every key.
+func MissingTemplates(lang language.Tag) []string {
+	target := errorTemplateSet[lang]
+
+	missing := make([]string, 0)
+	for key := range errorTemplateSet[defaultTemplateLanguage] {
+		if _, ok := target[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// bundledTemplateSet is a snapshot of the templates shipped with the
+// package, used by ResetMessageTemplates.
+var bundledTemplateSet = copyTemplateSet(errorTemplateSet)
+
+// checkerTemplateSet holds the templates registered along with checkers,
+// which ResetMessageTemplates restores after the bundled ones.
+var checkerTemplateSet = map[language.Tag]map[string]string{}
+
+// setCheckerTemplates sets the templates of the named checker by
+// language, and keeps them for ResetMessageTemplates.
+func setCheckerTemplates(name string, messages map[language.Tag]string) {
+	for tag, message := range messages {
+		if checkerTemplateSet[tag] == nil {
+			checkerTemplateSet[tag] = make(map[string]string)
+		}
+		checkerTemplateSet[tag][name] = message
+		SetMessageTemplates(map[string]string{name: message}, tag)
+	}
+}
+
+func copyTemplateSet(set map[language.Tag]map[string]string) map[language.Tag]map[string]string {
+	copied := make(map[language.Tag]map[string]string, len(set))
+	for tag, templates := range set {
+		copied[tag] = copyTemplates(templates)
+	}
+	return copied
+}
+
+func copyTemplates(templates map[string]string) map[string]string {
+	copied := make(map[string]string, len(templates))
+	for k, v := range templates {
+		copied[k] = v
+	}
+	return copied
+}
+
+// templateLanguageOf returns the first given language, or the package
+// default when none is given.
+func templateLanguageOf(lang []language.Tag) language.Tag {
+	if len(lang) > 0 {
+		return lang[0]
+	}
+	return defaultTemplateLanguage
+}
+
 var errorTemplateChinese = map[string]string{
 	"required":       "不能为空",
 	"min":            "应大于",
@@ -47,12 +183,42 @@ var errorTemplateChinese = map[string]string{
 	"idcard":         "不是合法的身份证号",
 	"equal":          "的值前后不相同",
 	"list":           "不是一个有效的值",
+	"excluded":       "必须为空",
+	"eqfield":        "应等于",
+	"nefield":        "不能等于",
+	"gtfield":        "应大于",
+	"gtefield":       "应大于或等于",
+	"ltfield":        "应小于",
+	"ltefield":       "应小于或等于",
+	"before":         "应早于",
+	"after":          "应晚于",
+	"between":        "应在{limit}范围内}}",
+	"datetime":       "应符合日期时间格式 ",
+	"weekday":        "不在允许的星期内",
+	"age":            "的年龄应在{limit}范围内}}",
+	"gt":             "应大于",
+	"lt":             "应小于",
+	"multipleof":     "应为{limit}的倍数}}",
+	"positive":       "应为正数",
+	"negative":       "应为负数",
+	"nonzero":        "不能为零",
+	"decimal":        "的整数部分不能超过{limit}位}}",
+	"decimalScale":   "的小数部分不能超过{limit}位}}",
+	"regex":          "格式不正确",
+	"pattern":        "格式不正确",
 
 	"_checkerNotFound":      "检查规则未找到}}",
 	"_unknownErrorTemplate": "{{未知错误}}",
 	"_paramError":           "检查规则入参错误}}",
 	"_valueTypeError":       "参数类型不正确}}",
 	"_fieldNotFound":        "{{字段不存在}}",
+	"_labelNested":          "{parent}·{child}",
+	"_labelElement":         "第{index}个{parent}的{child}",
+	"_labelItem":            "第{index}项的{child}",
+	"_syntaxError":          "检查规则在第{limit}个字符处格式错误}}",
+	"_or":                   "或",
+	"_not":                  "不允许使用该值",
+	"_invalid":              "不是一个有效的值",
 }
 
 var errorTemplateEnglish = map[string]string{
@@ -74,10 +240,40 @@ var errorTemplateEnglish = map[string]string{
 	"idcard":         " is not a valid ID card number",
 	"equal":          " the value before and after is not the same",
 	"list":           " is not a valid value",
+	"excluded":       " must be empty",
+	"eqfield":        " should be equal to ",
+	"nefield":        " should not be equal to ",
+	"gtfield":        " should be greater than ",
+	"gtefield":       " should be greater than or equal to ",
+	"ltfield":        " should be less than ",
+	"ltefield":       " should be less than or equal to ",
+	"before":         " should be before ",
+	"after":          " should be after ",
+	"between":        " should be within {limit}}}",
+	"datetime":       " should be a date/time in the format ",
+	"weekday":        " is not on an allowed day of the week",
+	"age":            " age should be within {limit}}}",
+	"gt":             " should be greater than ",
+	"lt":             " should be less than ",
+	"multipleof":     " should be a multiple of ",
+	"positive":       " should be positive",
+	"negative":       " should be negative",
+	"nonzero":        " can not be zero",
+	"decimal":        " can have at most {limit} integer digits}}",
+	"decimalScale":   " can have at most {limit} decimal places}}",
+	"regex":          " is not in the correct format",
+	"pattern":        " is not in the correct format",
 
 	"_checkerNotFound":      " check rule not found}}",
 	"_unknownErrorTemplate": "{{unknown error}}",
 	"_paramError":           " check rule parameter error}}",
 	"_valueTypeError":       " parameter type error}}",
 	"_fieldNotFound":        "{{field not found}}",
+	"_labelNested":          "{parent} {child}",
+	"_labelElement":         "{parent} #{index} {child}",
+	"_labelItem":            "#{index} {child}",
+	"_syntaxError":          " check rule syntax error at column {limit}}}",
+	"_or":                   " or",
+	"_not":                  " is not allowed",
+	"_invalid":              " is not a valid value",
 }
@@ -0,0 +1,405 @@
+package govalid
+
+// The locale packs below follow the same template conventions as the
+// Chinese and English sets in template.go: the field label is prepended
+// unless the template starts with "{{", and the limit value is appended
+// unless the template ends with "}}".
+
+var errorTemplateTraditionalChinese = map[string]string{
+	"required":       "不能為空",
+	"min":            "應大於",
+	"max":            "應小於",
+	"minlen":         "長度應大於",
+	"maxlen":         "長度應小於",
+	"alpha":          "必須只包含字母",
+	"alphanumeric":   "只能含有字母或數字",
+	"alphadash":      "只含有數字或字母以及底線",
+	"firstCharAlpha": "的第一個字元必須為字母",
+	"lastUnderline":  "的最後一個字元不能為底線",
+	"email":          "不是合法的電子郵箱格式",
+	"ipv4":           "不是合法的 IPv4 位址格式",
+	"mobile":         "不是合法的手機號碼",
+	"tel":            "不是合法的市話號碼",
+	"phone":          "不是合法的號碼",
+	"idcard":         "不是合法的身分證號",
+	"equal":          "的值前後不相同",
+	"list":           "不是一個有效的值",
+	"excluded":       "必須為空",
+	"eqfield":        "應等於",
+	"nefield":        "不能等於",
+	"gtfield":        "應大於",
+	"gtefield":       "應大於或等於",
+	"ltfield":        "應小於",
+	"ltefield":       "應小於或等於",
+	"before":         "應早於",
+	"after":          "應晚於",
+	"between":        "應在{limit}範圍內}}",
+	"datetime":       "應符合日期時間格式 ",
+	"weekday":        "不在允許的星期內",
+	"age":            "的年齡應在{limit}範圍內}}",
+	"gt":             "應大於",
+	"lt":             "應小於",
+	"multipleof":     "應為{limit}的倍數}}",
+	"positive":       "應為正數",
+	"negative":       "應為負數",
+	"nonzero":        "不能為零",
+	"decimal":        "的整數部分不能超過{limit}位}}",
+	"decimalScale":   "的小數部分不能超過{limit}位}}",
+	"regex":          "格式不正確",
+	"pattern":        "格式不正確",
+
+	"_checkerNotFound":      "檢查規則未找到}}",
+	"_unknownErrorTemplate": "{{未知錯誤}}",
+	"_paramError":           "檢查規則參數錯誤}}",
+	"_valueTypeError":       "參數類型不正確}}",
+	"_fieldNotFound":        "{{欄位不存在}}",
+	"_labelNested":          "{parent}·{child}",
+	"_labelElement":         "第{index}個{parent}的{child}",
+	"_labelItem":            "第{index}項的{child}",
+	"_syntaxError":          "檢查規則在第{limit}個字元處格式錯誤}}",
+	"_or":                   "或",
+	"_not":                  "不允許使用該值",
+	"_invalid":              "不是一個有效的值",
+}
+
+var errorTemplateJapanese = map[string]string{
+	"required":       "は必須です",
+	"min":            "は{limit}以上である必要があります}}",
+	"max":            "は{limit}以下である必要があります}}",
+	"minlen":         "の長さは{limit}以上である必要があります}}",
+	"maxlen":         "の長さは{limit}以下である必要があります}}",
+	"alpha":          "は英字のみ使用できます",
+	"alphanumeric":   "は英数字のみ使用できます",
+	"alphadash":      "は英数字とアンダースコアのみ使用できます",
+	"firstCharAlpha": "の最初の文字は英字である必要があります",
+	"lastUnderline":  "の最後の文字にアンダースコアは使用できません",
+	"email":          "は有効なメールアドレスではありません",
+	"ipv4":           "は有効な IPv4 アドレスではありません",
+	"mobile":         "は有効な携帯電話番号ではありません",
+	"tel":            "は有効な固定電話番号ではありません",
+	"phone":          "は有効な電話番号ではありません",
+	"idcard":         "は有効な身分証番号ではありません",
+	"equal":          "の値が一致しません",
+	"list":           "は有効な値ではありません",
+	"excluded":       "は空である必要があります",
+	"eqfield":        "は{limit}と等しい必要があります}}",
+	"nefield":        "は{limit}と異なる必要があります}}",
+	"gtfield":        "は{limit}より大きい必要があります}}",
+	"gtefield":       "は{limit}以上である必要があります}}",
+	"ltfield":        "は{limit}より小さい必要があります}}",
+	"ltefield":       "は{limit}以下である必要があります}}",
+	"before":         "は{limit}より前である必要があります}}",
+	"after":          "は{limit}より後である必要があります}}",
+	"between":        "は{limit}の範囲内である必要があります}}",
+	"datetime":       "は{limit}形式の日時である必要があります}}",
+	"weekday":        "は許可されていない曜日です",
+	"age":            "の年齢は{limit}の範囲内である必要があります}}",
+	"gt":             "は{limit}より大きい必要があります}}",
+	"lt":             "は{limit}より小さい必要があります}}",
+	"multipleof":     "は{limit}の倍数である必要があります}}",
+	"positive":       "は正の数である必要があります",
+	"negative":       "は負の数である必要があります",
+	"nonzero":        "は0以外である必要があります",
+	"decimal":        "の整数部は{limit}桁以内である必要があります}}",
+	"decimalScale":   "の小数部は{limit}桁以内である必要があります}}",
+	"regex":          "の形式が正しくありません",
+	"pattern":        "の形式が正しくありません",
+
+	"_checkerNotFound":      "の検証ルールが見つかりません}}",
+	"_unknownErrorTemplate": "{{不明なエラー}}",
+	"_paramError":           "の検証ルールのパラメータが不正です}}",
+	"_valueTypeError":       "のパラメータの型が不正です}}",
+	"_fieldNotFound":        "{{フィールドが存在しません}}",
+	"_labelNested":          "{parent}の{child}",
+	"_labelElement":         "{index}番目の{parent}の{child}",
+	"_labelItem":            "{index}番目の{child}",
+	"_syntaxError":          "の検証ルールの{limit}文字目に構文エラーがあります}}",
+	"_or":                   "、または",
+	"_not":                  "は許可されていない値です",
+	"_invalid":              "は有効な値ではありません",
+}
+
+var errorTemplateKorean = map[string]string{
+	"required":       "은(는) 필수 항목입니다",
+	"min":            "은(는) {limit} 이상이어야 합니다}}",
+	"max":            "은(는) {limit} 이하여야 합니다}}",
+	"minlen":         "의 길이는 {limit} 이상이어야 합니다}}",
+	"maxlen":         "의 길이는 {limit} 이하여야 합니다}}",
+	"alpha":          "은(는) 영문자만 포함해야 합니다",
+	"alphanumeric":   "은(는) 영문자 또는 숫자만 포함해야 합니다",
+	"alphadash":      "은(는) 영문자, 숫자, 밑줄만 포함해야 합니다",
+	"firstCharAlpha": "의 첫 글자는 영문자여야 합니다",
+	"lastUnderline":  "의 마지막 글자는 밑줄일 수 없습니다",
+	"email":          "은(는) 올바른 이메일 주소가 아닙니다",
+	"ipv4":           "은(는) 올바른 IPv4 주소가 아닙니다",
+	"mobile":         "은(는) 올바른 휴대전화 번호가 아닙니다",
+	"tel":            "은(는) 올바른 유선전화 번호가 아닙니다",
+	"phone":          "은(는) 올바른 전화번호가 아닙니다",
+	"idcard":         "은(는) 올바른 신분증 번호가 아닙니다",
+	"equal":          "의 값이 일치하지 않습니다",
+	"list":           "은(는) 유효한 값이 아닙니다",
+	"excluded":       "은(는) 비어 있어야 합니다",
+	"eqfield":        "은(는) {limit}와(과) 같아야 합니다}}",
+	"nefield":        "은(는) {limit}와(과) 달라야 합니다}}",
+	"gtfield":        "은(는) {limit}보다 커야 합니다}}",
+	"gtefield":       "은(는) {limit} 이상이어야 합니다}}",
+	"ltfield":        "은(는) {limit}보다 작아야 합니다}}",
+	"ltefield":       "은(는) {limit} 이하여야 합니다}}",
+	"before":         "은(는) {limit} 이전이어야 합니다}}",
+	"after":          "은(는) {limit} 이후여야 합니다}}",
+	"between":        "은(는) {limit} 범위 내여야 합니다}}",
+	"datetime":       "은(는) {limit} 형식의 날짜/시간이어야 합니다}}",
+	"weekday":        "은(는) 허용되지 않은 요일입니다",
+	"age":            "의 나이는 {limit} 범위 내여야 합니다}}",
+	"gt":             "은(는) {limit}보다 커야 합니다}}",
+	"lt":             "은(는) {limit}보다 작아야 합니다}}",
+	"multipleof":     "은(는) {limit}의 배수여야 합니다}}",
+	"positive":       "은(는) 양수여야 합니다",
+	"negative":       "은(는) 음수여야 합니다",
+	"nonzero":        "은(는) 0이 아니어야 합니다",
+	"decimal":        "의 정수 부분은 최대 {limit}자리여야 합니다}}",
+	"decimalScale":   "의 소수 부분은 최대 {limit}자리여야 합니다}}",
+	"regex":          "의 형식이 올바르지 않습니다",
+	"pattern":        "의 형식이 올바르지 않습니다",
+
+	"_checkerNotFound":      "의 검증 규칙을 찾을 수 없습니다}}",
+	"_unknownErrorTemplate": "{{알 수 없는 오류}}",
+	"_paramError":           "의 검증 규칙 매개변수 오류}}",
+	"_valueTypeError":       "의 매개변수 형식 오류}}",
+	"_fieldNotFound":        "{{필드가 존재하지 않습니다}}",
+	"_labelNested":          "{parent}의 {child}",
+	"_labelElement":         "{index}번째 {parent}의 {child}",
+	"_labelItem":            "{index}번째 항목의 {child}",
+	"_syntaxError":          "의 검증 규칙 {limit}번째 문자에 구문 오류가 있습니다}}",
+	"_or":                   " 또는 ",
+	"_not":                  "은(는) 허용되지 않는 값입니다",
+	"_invalid":              "은(는) 유효한 값이 아닙니다",
+}
+
+var errorTemplateFrench = map[string]string{
+	"required":       " ne peut pas être vide",
+	"min":            " doit être supérieur à ",
+	"max":            " doit être inférieur à ",
+	"minlen":         " doit avoir une longueur supérieure à ",
+	"maxlen":         " doit avoir une longueur inférieure à ",
+	"alpha":          " ne doit contenir que des lettres",
+	"alphanumeric":   " ne doit contenir que des lettres ou des chiffres",
+	"alphadash":      " ne doit contenir que des lettres, des chiffres ou des tirets bas",
+	"firstCharAlpha": " : le premier caractère doit être une lettre",
+	"lastUnderline":  " : le dernier caractère ne peut pas être un tiret bas",
+	"email":          " n'est pas une adresse e-mail valide",
+	"ipv4":           " n'est pas une adresse IPv4 valide",
+	"mobile":         " n'est pas un numéro de mobile valide",
+	"tel":            " n'est pas un numéro de téléphone fixe valide",
+	"phone":          " n'est pas un numéro de téléphone valide",
+	"idcard":         " n'est pas un numéro de carte d'identité valide",
+	"equal":          " : les deux valeurs ne correspondent pas",
+	"list":           " n'est pas une valeur valide",
+	"excluded":       " doit être vide",
+	"eqfield":        " doit être égal à ",
+	"nefield":        " ne doit pas être égal à ",
+	"gtfield":        " doit être supérieur à ",
+	"gtefield":       " doit être supérieur ou égal à ",
+	"ltfield":        " doit être inférieur à ",
+	"ltefield":       " doit être inférieur ou égal à ",
+	"before":         " doit être antérieur à ",
+	"after":          " doit être postérieur à ",
+	"between":        " doit être compris dans {limit}}}",
+	"datetime":       " doit être une date/heure au format ",
+	"weekday":        " ne tombe pas un jour de la semaine autorisé",
+	"age":            " : l'âge doit être compris dans {limit}}}",
+	"gt":             " doit être strictement supérieur à ",
+	"lt":             " doit être strictement inférieur à ",
+	"multipleof":     " doit être un multiple de ",
+	"positive":       " doit être positif",
+	"negative":       " doit être négatif",
+	"nonzero":        " ne peut pas être nul",
+	"decimal":        " peut avoir au plus {limit} chiffres entiers}}",
+	"decimalScale":   " peut avoir au plus {limit} décimales}}",
+	"regex":          " n'est pas au bon format",
+	"pattern":        " n'est pas au bon format",
+
+	"_checkerNotFound":      " : règle de validation introuvable}}",
+	"_unknownErrorTemplate": "{{erreur inconnue}}",
+	"_paramError":           " : paramètre de règle de validation incorrect}}",
+	"_valueTypeError":       " : type de paramètre incorrect}}",
+	"_fieldNotFound":        "{{champ introuvable}}",
+	"_labelNested":          "{parent} › {child}",
+	"_labelElement":         "{parent} n°{index} › {child}",
+	"_labelItem":            "n°{index} › {child}",
+	"_syntaxError":          " : erreur de syntaxe de la règle de validation à la colonne {limit}}}",
+	"_or":                   " ou",
+	"_not":                  " n'est pas autorisé",
+	"_invalid":              " n'est pas une valeur valide",
+}
+
+var errorTemplateGerman = map[string]string{
+	"required":       " darf nicht leer sein",
+	"min":            " muss größer sein als ",
+	"max":            " muss kleiner sein als ",
+	"minlen":         " muss länger sein als ",
+	"maxlen":         " muss kürzer sein als ",
+	"alpha":          " darf nur Buchstaben enthalten",
+	"alphanumeric":   " darf nur Buchstaben oder Ziffern enthalten",
+	"alphadash":      " darf nur Buchstaben, Ziffern oder Unterstriche enthalten",
+	"firstCharAlpha": ": das erste Zeichen muss ein Buchstabe sein",
+	"lastUnderline":  ": das letzte Zeichen darf kein Unterstrich sein",
+	"email":          " ist keine gültige E-Mail-Adresse",
+	"ipv4":           " ist keine gültige IPv4-Adresse",
+	"mobile":         " ist keine gültige Mobilfunknummer",
+	"tel":            " ist keine gültige Festnetznummer",
+	"phone":          " ist keine gültige Telefonnummer",
+	"idcard":         " ist keine gültige Ausweisnummer",
+	"equal":          ": die Werte stimmen nicht überein",
+	"list":           " ist kein gültiger Wert",
+	"excluded":       " muss leer sein",
+	"eqfield":        " muss übereinstimmen mit ",
+	"nefield":        " darf nicht übereinstimmen mit ",
+	"gtfield":        " muss größer sein als ",
+	"gtefield":       " muss mindestens so groß sein wie ",
+	"ltfield":        " muss kleiner sein als ",
+	"ltefield":       " darf höchstens so groß sein wie ",
+	"before":         " muss vor ",
+	"after":          " muss nach ",
+	"between":        " muss innerhalb von {limit} liegen}}",
+	"datetime":       " muss ein Datum/eine Uhrzeit im Format ",
+	"weekday":        " fällt nicht auf einen erlaubten Wochentag",
+	"age":            ": das Alter muss innerhalb von {limit} liegen}}",
+	"gt":             " muss größer sein als ",
+	"lt":             " muss kleiner sein als ",
+	"multipleof":     " muss ein Vielfaches sein von ",
+	"positive":       " muss positiv sein",
+	"negative":       " muss negativ sein",
+	"nonzero":        " darf nicht null sein",
+	"decimal":        " darf höchstens {limit} Vorkommastellen haben}}",
+	"decimalScale":   " darf höchstens {limit} Nachkommastellen haben}}",
+	"regex":          " hat ein ungültiges Format",
+	"pattern":        " hat ein ungültiges Format",
+
+	"_checkerNotFound":      ": Prüfregel nicht gefunden}}",
+	"_unknownErrorTemplate": "{{unbekannter Fehler}}",
+	"_paramError":           ": fehlerhafter Parameter der Prüfregel}}",
+	"_valueTypeError":       ": ungültiger Parametertyp}}",
+	"_fieldNotFound":        "{{Feld nicht gefunden}}",
+	"_labelNested":          "{parent} › {child}",
+	"_labelElement":         "{parent} Nr. {index} › {child}",
+	"_labelItem":            "Nr. {index} › {child}",
+	"_syntaxError":          ": Syntaxfehler der Prüfregel in Spalte {limit}}}",
+	"_or":                   " oder",
+	"_not":                  " ist nicht erlaubt",
+	"_invalid":              " ist kein gültiger Wert",
+}
+
+var errorTemplateSpanish = map[string]string{
+	"required":       " no puede estar vacío",
+	"min":            " debe ser mayor que ",
+	"max":            " debe ser menor que ",
+	"minlen":         " debe tener una longitud mayor que ",
+	"maxlen":         " debe tener una longitud menor que ",
+	"alpha":          " solo puede contener letras",
+	"alphanumeric":   " solo puede contener letras o números",
+	"alphadash":      " solo puede contener letras, números o guiones bajos",
+	"firstCharAlpha": ": el primer carácter debe ser una letra",
+	"lastUnderline":  ": el último carácter no puede ser un guion bajo",
+	"email":          " no es una dirección de correo electrónico válida",
+	"ipv4":           " no es una dirección IPv4 válida",
+	"mobile":         " no es un número de móvil válido",
+	"tel":            " no es un número de teléfono fijo válido",
+	"phone":          " no es un número de teléfono válido",
+	"idcard":         " no es un número de documento de identidad válido",
+	"equal":          ": los valores no coinciden",
+	"list":           " no es un valor válido",
+	"excluded":       " debe estar vacío",
+	"eqfield":        " debe ser igual a ",
+	"nefield":        " no debe ser igual a ",
+	"gtfield":        " debe ser mayor que ",
+	"gtefield":       " debe ser mayor o igual que ",
+	"ltfield":        " debe ser menor que ",
+	"ltefield":       " debe ser menor o igual que ",
+	"before":         " debe ser anterior a ",
+	"after":          " debe ser posterior a ",
+	"between":        " debe estar dentro de {limit}}}",
+	"datetime":       " debe ser una fecha/hora con el formato ",
+	"weekday":        " no cae en un día de la semana permitido",
+	"age":            ": la edad debe estar dentro de {limit}}}",
+	"gt":             " debe ser mayor que ",
+	"lt":             " debe ser menor que ",
+	"multipleof":     " debe ser múltiplo de ",
+	"positive":       " debe ser positivo",
+	"negative":       " debe ser negativo",
+	"nonzero":        " no puede ser cero",
+	"decimal":        " puede tener como máximo {limit} dígitos enteros}}",
+	"decimalScale":   " puede tener como máximo {limit} decimales}}",
+	"regex":          " no tiene el formato correcto",
+	"pattern":        " no tiene el formato correcto",
+
+	"_checkerNotFound":      ": regla de validación no encontrada}}",
+	"_unknownErrorTemplate": "{{error desconocido}}",
+	"_paramError":           ": parámetro de la regla de validación incorrecto}}",
+	"_valueTypeError":       ": tipo de parámetro incorrecto}}",
+	"_fieldNotFound":        "{{campo no encontrado}}",
+	"_labelNested":          "{parent} › {child}",
+	"_labelElement":         "{parent} n.º {index} › {child}",
+	"_labelItem":            "n.º {index} › {child}",
+	"_syntaxError":          ": error de sintaxis de la regla de validación en la columna {limit}}}",
+	"_or":                   " o",
+	"_not":                  " no está permitido",
+	"_invalid":              " no es un valor válido",
+}
+
+var errorTemplateRussian = map[string]string{
+	"required":       " не может быть пустым",
+	"min":            " должно быть больше ",
+	"max":            " должно быть меньше ",
+	"minlen":         ": длина должна быть больше ",
+	"maxlen":         ": длина должна быть меньше ",
+	"alpha":          " должно содержать только буквы",
+	"alphanumeric":   " должно содержать только буквы или цифры",
+	"alphadash":      " должно содержать только буквы, цифры или символы подчёркивания",
+	"firstCharAlpha": ": первый символ должен быть буквой",
+	"lastUnderline":  ": последний символ не может быть подчёркиванием",
+	"email":          " не является корректным адресом электронной почты",
+	"ipv4":           " не является корректным IPv4-адресом",
+	"mobile":         " не является корректным номером мобильного телефона",
+	"tel":            " не является корректным номером стационарного телефона",
+	"phone":          " не является корректным номером телефона",
+	"idcard":         " не является корректным номером удостоверения личности",
+	"equal":          ": значения не совпадают",
+	"list":           " не является допустимым значением",
+	"excluded":       " должно быть пустым",
+	"eqfield":        " должно быть равно полю ",
+	"nefield":        " не должно быть равно полю ",
+	"gtfield":        " должно быть больше поля ",
+	"gtefield":       " должно быть больше или равно полю ",
+	"ltfield":        " должно быть меньше поля ",
+	"ltefield":       " должно быть меньше или равно полю ",
+	"before":         " должно быть раньше ",
+	"after":          " должно быть позже ",
+	"between":        " должно находиться в диапазоне {limit}}}",
+	"datetime":       " должно быть датой/временем в формате ",
+	"weekday":        " приходится на недопустимый день недели",
+	"age":            ": возраст должен находиться в диапазоне {limit}}}",
+	"gt":             " должно быть больше ",
+	"lt":             " должно быть меньше ",
+	"multipleof":     " должно быть кратно ",
+	"positive":       " должно быть положительным",
+	"negative":       " должно быть отрицательным",
+	"nonzero":        " не может быть равно нулю",
+	"decimal":        " может содержать не более {limit} цифр в целой части}}",
+	"decimalScale":   " может содержать не более {limit} знаков после запятой}}",
+	"regex":          " имеет неверный формат",
+	"pattern":        " имеет неверный формат",
+
+	"_checkerNotFound":      ": правило проверки не найдено}}",
+	"_unknownErrorTemplate": "{{неизвестная ошибка}}",
+	"_paramError":           ": неверный параметр правила проверки}}",
+	"_valueTypeError":       ": неверный тип параметра}}",
+	"_fieldNotFound":        "{{поле не найдено}}",
+	"_labelNested":          "{parent} › {child}",
+	"_labelElement":         "{parent} №{index} › {child}",
+	"_labelItem":            "№{index} › {child}",
+	"_syntaxError":          ": синтаксическая ошибка правила проверки в позиции {limit}}}",
+	"_or":                   " или",
+	"_not":                  " не допускается",
+	"_invalid":              " не является допустимым значением",
+}
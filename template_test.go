@@ -0,0 +1,198 @@
+package govalid
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// =============================================================================
+// Bundled locale packs must stay complete. A new checker that only ships
+// Chinese/English templates fails here instead of silently rendering
+// "unknown error" in the other locales.
+// =============================================================================
+
+func Test_MissingTemplates_BundledLocalesComplete(t *testing.T) {
+	for tag := range errorTemplateSet {
+		tag := tag
+		t.Run(tag.String(), func(t *testing.T) {
+			assert.Empty(t, MissingTemplates(tag))
+		})
+	}
+}
+
+func Test_MissingTemplates(t *testing.T) {
+	t.Run("unregistered locale reports every key", func(t *testing.T) {
+		got := MissingTemplates(language.Vietnamese)
+		assert.Equal(t, len(errorTemplateChinese), len(got))
+		assert.Contains(t, got, "required")
+		assert.IsNonDecreasing(t, got)
+	})
+
+	t.Run("partially translated locale", func(t *testing.T) {
+		loc := language.Vietnamese
+		defer delete(errorTemplateSet, loc)
+
+		SetMessageTemplates(map[string]string{
+			"required": " không được để trống",
+		}, loc)
+
+		got := MissingTemplates(loc)
+		assert.NotContains(t, got, "required")
+		assert.Contains(t, got, "email")
+	})
+}
+
+// =============================================================================
+// Bundled locale packs render through Check
+// =============================================================================
+
+func Test_BundledLocales(t *testing.T) {
+	v := struct {
+		Name string `valid:"required" label:"Name"`
+		Age  int    `valid:"min:18" label:"Age"`
+	}{Age: 3}
+
+	for _, tc := range []struct {
+		lang language.Tag
+		want []string
+	}{
+		{language.TraditionalChinese, []string{"Name不能為空", "Age應大於18"}},
+		{language.Japanese, []string{"Nameは必須です", "Ageは18以上である必要があります"}},
+		{language.Korean, []string{"Name은(는) 필수 항목입니다", "Age은(는) 18 이상이어야 합니다"}},
+		{language.French, []string{"Name ne peut pas être vide", "Age doit être supérieur à 18"}},
+		{language.German, []string{"Name darf nicht leer sein", "Age muss größer sein als 18"}},
+		{language.Spanish, []string{"Name no puede estar vacío", "Age debe ser mayor que 18"}},
+		{language.Russian, []string{"Name не может быть пустым", "Age должно быть больше 18"}},
+	} {
+		tc := tc
+		t.Run(tc.lang.String(), func(t *testing.T) {
+			errs, ok := Check(v, tc.lang)
+			assert.False(t, ok)
+			assert.Equal(t, len(tc.want), len(errs))
+			for i, want := range tc.want {
+				assert.Equal(t, want, errs[i].Error())
+			}
+		})
+	}
+}
+
+func Test_RegionalLocaleFallsBackToParent(t *testing.T) {
+	v := struct {
+		Name string `valid:"required" label:"Name"`
+	}{}
+
+	t.Run("en-US uses english", func(t *testing.T) {
+		errs, _ := Check(v, language.AmericanEnglish)
+		assert.Equal(t, "Name can not be empty", errs[0].Error())
+	})
+
+	t.Run("zh-TW uses traditional chinese", func(t *testing.T) {
+		errs, _ := Check(v, language.MustParse("zh-TW"))
+		assert.Equal(t, "Name不能為空", errs[0].Error())
+	})
+}
+
+// =============================================================================
+// Read API: MessageTemplate / MessageTemplates / Languages
+// =============================================================================
+
+func Test_MessageTemplate(t *testing.T) {
+	got, ok := MessageTemplate("required")
+	assert.True(t, ok)
+	assert.Equal(t, "不能为空", got)
+
+	got, ok = MessageTemplate("required", language.English)
+	assert.True(t, ok)
+	assert.Equal(t, " can not be empty", got)
+
+	_, ok = MessageTemplate("definitelyNotAKey")
+	assert.False(t, ok)
+
+	_, ok = MessageTemplate("required", language.Vietnamese)
+	assert.False(t, ok)
+}
+
+func Test_MessageTemplates_ReturnsCopy(t *testing.T) {
+	templates := MessageTemplates(language.English)
+	assert.Equal(t, " can not be empty", templates["required"])
+
+	templates["required"] = "mutated"
+	got, _ := MessageTemplate("required", language.English)
+	assert.Equal(t, " can not be empty", got)
+
+	assert.Nil(t, MessageTemplates(language.Vietnamese))
+}
+
+func Test_Languages(t *testing.T) {
+	tags := Languages()
+	assert.Contains(t, tags, language.Chinese)
+	assert.Contains(t, tags, language.English)
+	assert.Contains(t, tags, language.Japanese)
+	assert.NotContains(t, tags, language.Vietnamese)
+
+	for i := 1; i < len(tags); i++ {
+		assert.Less(t, tags[i-1].String(), tags[i].String())
+	}
+}
+
+// =============================================================================
+// Write API: ResetMessageTemplates / RemoveMessageTemplate
+// =============================================================================
+
+func Test_ResetMessageTemplates(t *testing.T) {
+	t.Run("bundled language restores defaults", func(t *testing.T) {
+		defer ResetMessageTemplates(language.English)
+
+		SetMessageTemplates(map[string]string{
+			"required": " is mandatory",
+			"brandNew": " is brand new",
+		}, language.English)
+		RemoveMessageTemplate("email", language.English)
+
+		ResetMessageTemplates(language.English)
+
+		got, _ := MessageTemplate("required", language.English)
+		assert.Equal(t, " can not be empty", got)
+		_, ok := MessageTemplate("brandNew", language.English)
+		assert.False(t, ok)
+		_, ok = MessageTemplate("email", language.English)
+		assert.True(t, ok)
+	})
+
+	t.Run("default language", func(t *testing.T) {
+		defer ResetMessageTemplates()
+
+		SetMessageTemplates(map[string]string{"required": "必填"})
+		ResetMessageTemplates()
+
+		v := struct {
+			Name string `valid:"required" label:"姓名"`
+		}{}
+		errs, _ := Check(v)
+		assert.Equal(t, "姓名不能为空", errs[0].Error())
+	})
+
+	t.Run("unbundled language is unregistered", func(t *testing.T) {
+		SetMessageTemplates(map[string]string{"required": " không được để trống"}, language.Vietnamese)
+		ResetMessageTemplates(language.Vietnamese)
+		assert.NotContains(t, Languages(), language.Vietnamese)
+	})
+}
+
+func Test_RemoveMessageTemplate(t *testing.T) {
+	defer ResetMessageTemplates(language.English)
+
+	RemoveMessageTemplate("required", language.English)
+
+	v := struct {
+		Name string `valid:"required" label:"Name"`
+	}{}
+	errs, _ := Check(v, language.English)
+	assert.Equal(t, "unknown error", errs[0].Error())
+
+	// Removing from an unregistered language is a no-op.
+	RemoveMessageTemplate("required", language.Vietnamese)
+	assert.NotContains(t, Languages(), language.Vietnamese)
+}
@@ -0,0 +1,71 @@
+package govalid
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// NoTypeRules is the name of the rule that opts a field out of the rules
+// registered for its type with RegisterTypeRules, e.g. `valid:"-type"`.
+const NoTypeRules = "-type"
+
+// typeRules is the registry of the rules registered with
+// RegisterTypeRules, keyed by type. They are parsed once, when they are
+// registered.
+var typeRules = map[reflect.Type][]*rule{}
+
+// RegisterTypeRules registers rules for every field of the type of v, or
+// of a pointer to it, wherever it is declared. They are checked before the
+// field's own rules, unless its tag has a -type rule. Registering rules for
+// a type again replaces them.
+//
+// Example:
+//
+//	type Email string
+//
+//	govalid.RegisterTypeRules(Email(""), "email;maxlen:254")
+func RegisterTypeRules(v interface{}, rules string) error {
+	typ := reflect.TypeOf(v)
+	if typ == nil || typ.Name() == "" || typ.PkgPath() == "" {
+		return fmt.Errorf("register type rules: %T is not a defined type", v)
+	}
+	parsed, err := parseRules(rules)
+	if err != nil {
+		return fmt.Errorf("register type rules of %s: %v", typ, err)
+	}
+	typeRules[typ] = parsed
+	return nil
+}
+
+// fieldTypeRules returns the rules registered for the type of a field,
+// reading through pointers.
+func fieldTypeRules(typ reflect.Type) ([]*rule, bool) {
+	for {
+		if rules, ok := typeRules[typ]; ok {
+			return rules, true
+		}
+		if typ.Kind() != reflect.Ptr {
+			return nil, false
+		}
+		typ = typ.Elem()
+	}
+}
+
+// withTypeRules returns the rules of a field of type typ preceded by the
+// rules registered for its type, unless they have a NoTypeRules rule.
+func withTypeRules(typ reflect.Type, rules []*rule) []*rule {
+	registered, ok := fieldTypeRules(typ)
+	if !ok {
+		return rules
+	}
+	for _, r := range rules {
+		if r.checker == NoTypeRules {
+			return rules
+		}
+	}
+	return append(registered[:len(registered):len(registered)], rules...)
+}
+
+func noTypeRules(c CheckerContext) *ErrContext {
+	return nil
+}
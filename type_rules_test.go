@@ -0,0 +1,103 @@
+package govalid
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testEmail string
+
+type testMoney int64
+
+// registerTypeRules registers type rules for the duration of a test.
+func registerTypeRules(t *testing.T, v interface{}, rules string) {
+	t.Helper()
+	assert.Nil(t, RegisterTypeRules(v, rules))
+	t.Cleanup(func() { delete(typeRules, reflect.TypeOf(v)) })
+}
+
+func Test_RegisterTypeRules(t *testing.T) {
+	registerTypeRules(t, testEmail(""), "email;maxlen:20")
+	registerTypeRules(t, testMoney(0), "min:0")
+
+	type contact struct {
+		Email testEmail `label:"邮箱"`
+	}
+	type form struct {
+		Email    testEmail  `valid:"required" label:"邮箱"`
+		Backup   *testEmail `label:"备用邮箱"`
+		Price    testMoney  `label:"价格"`
+		Contacts []contact
+	}
+
+	backup := testEmail("b@example.com")
+	_, ok := Check(form{Email: "a@example.com", Backup: &backup, Price: 1, Contacts: []contact{{"c@example.com"}}})
+	assert.True(t, ok)
+
+	// The type's rules are checked before the field's own.
+	bad := testEmail("nope")
+	errs, _ := Check(form{Backup: &bad, Price: -1, Contacts: []contact{{"a-long-name@example.com"}}})
+	assert.Equal(t, 4, len(errs))
+	assert.Equal(t, "邮箱不能为空", errs[0].Error())
+	assert.Equal(t, "备用邮箱不是合法的电子邮箱格式", errs[1].Error())
+	assert.Equal(t, "价格应大于0", errs[2].Error())
+	assert.Equal(t, "Contacts[0].Email", errs[3].FieldPath)
+	assert.Equal(t, "邮箱长度应小于20", errs[3].Error())
+
+	// Plain fields of the underlying type are left alone.
+	_, ok = Check(struct {
+		Email string
+	}{Email: "nope"})
+	assert.True(t, ok)
+}
+
+func Test_RegisterTypeRules_OptOut(t *testing.T) {
+	registerTypeRules(t, testEmail(""), "email")
+
+	type form struct {
+		Template testEmail `valid:"-type;required" label:"模板"`
+		Draft    testEmail `valid:"-type"`
+	}
+
+	_, ok := Check(form{Template: "{{user}}@example.com", Draft: "nope"})
+	assert.True(t, ok)
+
+	errs, _ := Check(form{})
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "模板不能为空", errs[0].Error())
+
+	// Rules built in code can opt out too.
+	var plain struct {
+		Email testEmail
+	}
+	plain.Email = "nope"
+	_, ok = Fields(&plain).Check()
+	assert.False(t, ok)
+	_, ok = Fields(&plain, Field(&plain.Email, NewRule(NoTypeRules))).Check()
+	assert.True(t, ok)
+}
+
+func Test_RegisterTypeRules_Invalid(t *testing.T) {
+	assert.NotNil(t, RegisterTypeRules("", "email"))
+	assert.NotNil(t, RegisterTypeRules(struct{}{}, "required"))
+	assert.NotNil(t, RegisterTypeRules(nil, "required"))
+	assert.NotNil(t, RegisterTypeRules(testEmail(""), "email|"))
+	assert.NotContains(t, typeRules, reflect.TypeOf(testEmail("")))
+}
+
+func Test_Compile_TypeRules(t *testing.T) {
+	registerTypeRules(t, testMoney(0), "minlen:1")
+
+	err := Compile(reflect.TypeOf(struct {
+		Price  testMoney
+		Refund testMoney `valid:"-type;min:0"`
+	}{}))
+	if assert.NotNil(t, err) {
+		errs := err.(*CompileError).Errors
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "Price", errs[0].FieldPath)
+		assert.Equal(t, "minlen", errs[0].Checker)
+	}
+}
@@ -0,0 +1,114 @@
+package govalid
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+)
+
+var valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+// basicTypes maps each basic kind to its predeclared type, so values of
+// named types like `type Age int` can be converted for the checkers.
+var basicTypes = map[reflect.Kind]reflect.Type{
+	reflect.Bool:       reflect.TypeOf(false),
+	reflect.Int:        reflect.TypeOf(int(0)),
+	reflect.Int8:       reflect.TypeOf(int8(0)),
+	reflect.Int16:      reflect.TypeOf(int16(0)),
+	reflect.Int32:      reflect.TypeOf(int32(0)),
+	reflect.Int64:      reflect.TypeOf(int64(0)),
+	reflect.Uint:       reflect.TypeOf(uint(0)),
+	reflect.Uint8:      reflect.TypeOf(uint8(0)),
+	reflect.Uint16:     reflect.TypeOf(uint16(0)),
+	reflect.Uint32:     reflect.TypeOf(uint32(0)),
+	reflect.Uint64:     reflect.TypeOf(uint64(0)),
+	reflect.Uintptr:    reflect.TypeOf(uintptr(0)),
+	reflect.Float32:    reflect.TypeOf(float32(0)),
+	reflect.Float64:    reflect.TypeOf(float64(0)),
+	reflect.Complex64:  reflect.TypeOf(complex64(0)),
+	reflect.Complex128: reflect.TypeOf(complex128(0)),
+	reflect.String:     reflect.TypeOf(""),
+}
+
+// indirectValue returns the value a field stands for. Non-nil pointers are
+// dereferenced, driver.Valuer implementations such as sql.NullString are
+// unwrapped through their Value method, and values of named basic types
+// other than time.Duration are converted to the predeclared type of their
+// kind. It reports false if the value is absent, i.e. a nil pointer or a
+// NULL Valuer.
+func indirectValue(v interface{}) (interface{}, bool) {
+	// Valuers are unwrapped at most once: driver values are basic types,
+	// and a Valuer returning its own type would otherwise loop forever.
+	valued := false
+	for v != nil {
+		rv := reflect.ValueOf(v)
+		if (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) && rv.IsNil() {
+			return nil, false
+		}
+
+		if !valued && rv.Type().Implements(valuerType) {
+			value, err := v.(driver.Valuer).Value()
+			if err != nil {
+				return v, true
+			}
+			v, valued = value, true
+			continue
+		}
+
+		if rv.Kind() == reflect.Ptr {
+			v = rv.Elem().Interface()
+			continue
+		}
+
+		// time.Duration is kept, so that checkers can read their bounds
+		// as durations.
+		if basicType, ok := basicTypes[rv.Kind()]; ok && rv.Type() != basicType && rv.Type() != durationType {
+			return rv.Convert(basicType).Interface(), true
+		}
+		return v, true
+	}
+	return nil, false
+}
+
+// indirect is indirectValue for a reflect.Value. It returns the zero
+// reflect.Value if the value is absent.
+func indirect(v reflect.Value) reflect.Value {
+	if !v.IsValid() {
+		return v
+	}
+	value, ok := indirectValue(v.Interface())
+	if !ok {
+		return reflect.Value{}
+	}
+	return reflect.ValueOf(value)
+}
+
+// valueString formats the value a field stands for like fmt's %v, for
+// checkers that compare stringified values. Absent values format as "".
+func valueString(v reflect.Value) string {
+	v = indirect(v)
+	if !v.IsValid() {
+		return ""
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
+
+// indirectChecker wraps a built-in value checker so that it sees the value
+// the field stands for (see indirectValue) rather than a pointer, Valuer
+// or named type. Absent values are accepted; combine the rule with
+// required to reject them.
+func indirectChecker(checker CheckFunc) CheckFunc {
+	return func(c CheckerContext) *ErrContext {
+		if c.FieldValue == nil {
+			return checker(c)
+		}
+
+		value, ok := indirectValue(c.FieldValue)
+		if !ok {
+			return nil
+		}
+		c.FieldValue = value
+		c.FieldType = reflect.TypeOf(value)
+		return checker(c)
+	}
+}
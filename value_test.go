@@ -0,0 +1,168 @@
+package govalid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type nickname string
+
+type level int
+
+// brokenValuer fails to report its value.
+type brokenValuer string
+
+func (v brokenValuer) Value() (driver.Value, error) {
+	return nil, errors.New("broken")
+}
+
+// selfValuer reports itself as its value.
+type selfValuer string
+
+func (v selfValuer) Value() (driver.Value, error) {
+	return v, nil
+}
+
+func Test_PointerFields(t *testing.T) {
+	type patch struct {
+		Age   *int    `valid:"min:18" label:"年龄"`
+		Email *string `valid:"email" label:"邮箱"`
+		Name  *string `valid:"minlen:2" label:"名字"`
+	}
+
+	t.Run("nil pointers are absent", func(t *testing.T) {
+		_, ok := Check(patch{})
+		assert.True(t, ok)
+	})
+
+	t.Run("non-nil pointers are dereferenced", func(t *testing.T) {
+		age, email, name := 3, "nope", "a"
+		errs, ok := Check(patch{Age: &age, Email: &email, Name: &name})
+		assert.False(t, ok)
+		assert.Equal(t, 3, len(errs))
+		assert.Equal(t, "年龄应大于18", errs[0].Error())
+		assert.Equal(t, "邮箱不是合法的电子邮箱格式", errs[1].Error())
+		assert.Equal(t, "名字长度应大于2", errs[2].Error())
+		assert.Equal(t, 3, errs[0].FieldValue)
+	})
+
+	t.Run("valid values pass", func(t *testing.T) {
+		age, email := 20, "i@github.red"
+		_, ok := Check(patch{Age: &age, Email: &email})
+		assert.True(t, ok)
+	})
+
+	t.Run("pointer to pointer", func(t *testing.T) {
+		age := 3
+		p := &age
+		v := struct {
+			Age **int `valid:"min:18"`
+		}{Age: &p}
+		_, ok := Check(v)
+		assert.False(t, ok)
+	})
+
+	t.Run("required still rejects nil", func(t *testing.T) {
+		v := struct {
+			Age *int `valid:"required;min:18" label:"年龄"`
+		}{}
+		errs, ok := Check(v)
+		assert.False(t, ok)
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, "年龄不能为空", errs[0].Error())
+	})
+
+	t.Run("list and equal compare the pointee", func(t *testing.T) {
+		role := "admin"
+		v := struct {
+			Role    *string `valid:"list:admin,user"`
+			Confirm *string `valid:"equal:Role"`
+		}{Role: &role, Confirm: &role}
+		_, ok := Check(v)
+		assert.True(t, ok)
+	})
+}
+
+func Test_SQLNullFields(t *testing.T) {
+	type row struct {
+		Name  sql.NullString `valid:"minlen:2" label:"名称"`
+		Score sql.NullInt64  `valid:"max:100" label:"分数"`
+	}
+
+	t.Run("NULL values are absent", func(t *testing.T) {
+		_, ok := Check(row{})
+		assert.True(t, ok)
+	})
+
+	t.Run("valid values are checked", func(t *testing.T) {
+		errs, ok := Check(row{
+			Name:  sql.NullString{String: "a", Valid: true},
+			Score: sql.NullInt64{Int64: 200, Valid: true},
+		})
+		assert.False(t, ok)
+		assert.Equal(t, 2, len(errs))
+		assert.Equal(t, "名称长度应大于2", errs[0].Error())
+		assert.Equal(t, "分数应小于100", errs[1].Error())
+	})
+
+	t.Run("required rejects NULL", func(t *testing.T) {
+		v := struct {
+			Name *sql.NullString `valid:"required" label:"名称"`
+		}{Name: &sql.NullString{}}
+		errs, ok := Check(v)
+		assert.False(t, ok)
+		assert.Equal(t, "名称不能为空", errs[0].Error())
+	})
+}
+
+func Test_ValuerEmptiness(t *testing.T) {
+	type row struct {
+		Broken brokenValuer `valid:"required" label:"损坏"`
+		Self   selfValuer   `valid:"required" label:"自身"`
+	}
+
+	// A failing valuer is not empty, and a valuer returning itself is
+	// read once.
+	errs, _ := Check(row{})
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, "自身不能为空", errs[0].Error())
+
+	_, ok := Check(row{Self: "x"})
+	assert.True(t, ok)
+}
+
+func Test_NamedTypeFields(t *testing.T) {
+	v := struct {
+		Nick  nickname  `valid:"alpha" label:"昵称"`
+		Level level     `valid:"max:10" label:"等级"`
+		Ptr   *nickname `valid:"email" label:"邮箱"`
+	}{Nick: "e99", Level: 11}
+	errs, ok := Check(v)
+	assert.False(t, ok)
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "昵称必须只包含字母", errs[0].Error())
+	assert.Equal(t, "等级应小于10", errs[1].Error())
+}
+
+func Test_indirectValue(t *testing.T) {
+	n := 1
+	got, ok := indirectValue(&n)
+	assert.True(t, ok)
+	assert.Equal(t, 1, got)
+
+	var nilPtr *int
+	_, ok = indirectValue(nilPtr)
+	assert.False(t, ok)
+
+	got, ok = indirectValue(nickname("x"))
+	assert.True(t, ok)
+	assert.Equal(t, "x", got)
+
+	got, ok = indirectValue(sql.NullInt32{Int32: 3, Valid: true})
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), got)
+}